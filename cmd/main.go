@@ -1,118 +1,328 @@
 package main
 
 import (
-	"bufio"
+	"context"
 	"flag"
 	"fmt"
+	"github.com/Moleus/os-solver/pkg/gantt"
+	"github.com/Moleus/os-solver/pkg/report"
+	"github.com/Moleus/os-solver/pkg/trace"
 	"github.com/Moleus/os-solver/pkg/xlsx"
-	"github.com/xuri/excelize/v2"
 	"io"
 	"log/slog"
 	"os"
+	"path/filepath"
+	"slices"
 	"strconv"
 	"strings"
 
-	log "github.com/Moleus/os-solver/pkg/logging"
 	m "github.com/Moleus/os-solver/pkg/machine"
+	"github.com/Moleus/os-solver/pkg/sim"
 )
 
 var (
 	cpuCount          = flag.Int("cpus", 4, "Number of CPUs")
+	deviceCount       = flag.Int("devices", 2, "Number of IO devices")
 	inputFile         = flag.String("input", "", "Input file")
 	outputFile        = flag.String("output", "result.txt", "Output file")
 	procStatsFile     = flag.String("procStats", "procStats.txt", "Process stats file")
-	schedAlgo         = flag.String("algo", "fcfs", "Scheduling algorithm (default: fcfs). Possible values: fcfs, rr1, rr2, spn, srt, hrrn, rr")
+	taskStatsFile     = flag.String("taskStats", "", "Per-burst stats file, not written if empty")
+	queuesFile        = flag.String("queues", "", "Per-tick queue lengths and contents file, not written if empty")
+	schedAlgo         = flag.String("algo", "fcfs", "Scheduling algorithm (default: fcfs). Use -algo help to list registered algorithms")
 	roundRobinQuantum = flag.Int("quantum", 4, "Round robin quantum (default: 4)")
 	arrivalInterval   = flag.Int("interval", 2, "Proc arrival interval (default: 2)")
 	logLevel          = flag.String("log", "debug", "Log level (default: debug)")
 	exportXlsx        = flag.String("export-xlsx", "", "Path for creating xlsx report")
+	selectExpr        = flag.String("select", "", "Selection objective for -algo expr, e.g. \"max((w+s)/s)\" or \"min(remaining)\". Implies -algo expr")
+	preemptExpr       = flag.String("preempt", "", "Preemption rule for -algo expr, e.g. \"best < score\"")
+	starvation        = flag.Int("starvation", 50, "Flag processes which continuously waited in ready queue longer than this many ticks, 0 disables")
+	switchCost        = flag.Int("switch-cost", 0, "Ticks CPU spends on switching to another process, 0 disables")
+	tieBreak          = flag.String("tie-break", "new-first", "Order of processes entering CPU queue on the same tick: new-first, returning-first, by-id, by-arrival")
+	sweepOutput       = flag.String("sweep-output", "sweep.csv", "Sweep grid file, best settings are printed to stdout")
+	monteCarloRuns    = flag.Int("montecarlo", 0, "Compare algorithms on this many random workloads instead of reading input, 0 disables")
+	seed              = flag.Int64("seed", 1, "Seed of the first random workload for -montecarlo")
+	workloadSpec      = flag.String("gen", "", "Random workload spec for -montecarlo: procs=n,bursts=dist,cpu=dist,io=dist where dist is n, min..max or exp(mean)")
+	compareAlgos      = flag.String("algos", "", "Comma separated algorithms for -montecarlo, all registered if empty. With -export-html they are run on the input and compared in the report")
+	monteCarloOutput  = flag.String("mc-output", "montecarlo.csv", "Monte Carlo estimates file, win rates are written next to it with _winrates suffix")
+	ganttFile         = flag.String("gantt", "", "Gantt chart file, - for stdout, not written if empty")
+	ganttBy           = flag.String("gantt-by", "resource", "Rows of Gantt chart: resource or process")
+	ganttWidth        = flag.Int("gantt-width", 120, "Max columns of Gantt chart, longer runs are compressed. 0 disables compression")
+	exportSvg         = flag.String("export-svg", "", "Path for Gantt chart in svg, rows are chosen by -gantt-by")
+	exportHtml        = flag.String("export-html", "", "Path for self-contained html report")
+	exportTrace       = flag.String("export-trace", "", "Path for Chrome trace event json, opens in Perfetto")
+	eventsFile        = flag.String("events", "", "Event log file, one json object per line, not written if empty")
+	csvTimeline       = flag.String("csv-timeline", "", "Per-tick resource occupancy csv file, not written if empty")
+	csvStats          = flag.String("csv-stats", "", "Per-process stats csv file, not written if empty")
+	exportLatex       = flag.String("export-latex", "", "LaTeX fragment with TikZ Gantt chart and stats tables, rows are chosen by -gantt-by")
+	exportMarkdown    = flag.String("export-md", "", "Markdown section with Gantt chart and stats tables, rows are chosen by -gantt-by")
+	exportMermaid     = flag.String("export-mermaid", "", "Mermaid gantt diagram file, fenced as a code block for .md files. Rows are chosen by -gantt-by")
+	algoParams        = paramsFlag{}
+	sweepParams       = sweepFlag{}
 )
 
-func calcArrivalTime(procId int) int {
-	return procId * *arrivalInterval
+func init() {
+	flag.Var(algoParams, "param", "Algorithm param as name=value, can be repeated. Overrides -quantum")
+	flag.Var(&sweepParams, "sweep", "Run every combination instead of a single simulation: name=from..to[/step] or name=v1,v2,... where name is cpus, interval, switch-cost or int param of the algorithm, e.g. quantum with -algo rr. Can be repeated")
 }
 
-func ParseTask(task string) m.Task {
-	task = strings.TrimSpace(task)
-	var taskType m.ResourceType
-	taskTypeStr := task[:3]
-	switch taskTypeStr {
-	case "IO1":
-		taskType = m.IO1
-	case "IO2":
-		taskType = m.IO2
-	case "CPU":
-		taskType = m.CPU
+// paramsFlag - collects repeated -param name=value flags
+type paramsFlag sim.Params
+
+func (p paramsFlag) String() string {
+	names := make([]string, 0, len(p))
+	for name := range p {
+		names = append(names, name)
 	}
-	taskTime, err := strconv.Atoi(task[4 : len(task)-1])
-	if err != nil {
-		panic(err)
+	slices.Sort(names)
+	pairs := make([]string, len(names))
+	for i, name := range names {
+		pairs[i] = name + "=" + p[name]
 	}
+	return strings.Join(pairs, ",")
+}
 
-	return m.Task{ResouceType: taskType, TotalTime: taskTime}
+func (p paramsFlag) Set(s string) error {
+	name, value, ok := strings.Cut(s, "=")
+	if !ok || name == "" {
+		return fmt.Errorf("expected name=value, got %q", s)
+	}
+	p[name] = value
+	return nil
 }
 
-func ParseProcess(id int, line string, logger *slog.Logger, clock log.GlobalTimer) *m.Process {
-	line = strings.TrimSpace(line)
-	tasks := strings.Split(line, ";")
-	if tasks[len(tasks)-1] == "" {
-		tasks = tasks[:len(tasks)-1]
+// sweepFlag - collects repeated -sweep name=values flags
+type sweepFlag []sim.SweepParam
+
+func (s *sweepFlag) String() string {
+	names := make([]string, len(*s))
+	for i, param := range *s {
+		names[i] = param.Name
 	}
-	slog.Debug(fmt.Sprintf("Tasks: %v\n", tasks))
+	return strings.Join(names, ",")
+}
 
-	var parsedTasks = make([]m.Task, len(tasks))
-	for i, task := range tasks {
-		parsedTasks[i] = ParseTask(task)
+func (s *sweepFlag) Set(value string) error {
+	param, err := sim.ParseSweepParam(value)
+	if err != nil {
+		return err
 	}
-	process := m.NewProcess(id, calcArrivalTime(id), parsedTasks, logger, clock)
-	return process
+	*s = append(*s, param)
+	return nil
 }
 
-func ParseProcesses(r io.Reader, logger *slog.Logger, clock log.GlobalTimer) []*m.Process {
-	scanner := bufio.NewScanner(r)
-	var processes = make([]*m.Process, 0)
-	var i int
-	for scanner.Scan() {
-		process := ParseProcess(i, scanner.Text(), logger, clock)
-		i++
-		processes = append(processes, process)
+func isFlagSet(name string) bool {
+	set := false
+	flag.Visit(func(f *flag.Flag) {
+		if f.Name == name {
+			set = true
+		}
+	})
+	return set
+}
+
+func printAlgorithms(w io.Writer) {
+	fmt.Fprintf(w, "Registered algorithms:\n")
+	for _, algo := range sim.Algorithms() {
+		fmt.Fprintf(w, "  %-6s %s\n", algo.Name, algo.Description)
+		for _, param := range algo.Params {
+			fmt.Fprintf(w, "         -param %s=<%s> %s (default: %s)\n", param.Name, param.Kind, param.Description, param.Default)
+		}
 	}
-	return processes
 }
 
 func snapshotState(w io.Writer, row string) {
 	fmt.Fprintf(w, "%s\n", row)
 }
 
-func printProcsStats(w io.Writer, procs []*m.Process) {
-	fmt.Fprintf(w, "Process\tArrival\tService\tWaiting\tFinish time\tTurnaround (Tr)\tTr/Ts\n")
-	for _, proc := range procs {
-		stats := proc.GetStats()
-		normalizedTurnaround := float64(stats.TurnaroundTime) / float64(stats.ServiceTime)
-		fmt.Fprintf(w, "%d\t%d\t%d\t%d\t%d\t%d\t%f\n", stats.ProcId+1, stats.EntranceTime, stats.ServiceTime, stats.ReadyOrBlockedTime, stats.ExitTime, stats.TurnaroundTime, normalizedTurnaround)
-	}
-}
-
-func getScheduler(schedAlgo string, procQueue *m.ProcQueue, cpuCount int) (m.Evictor, m.SelectionFunction) {
-	switch schedAlgo {
-	case "fcfs":
-		return m.NewNonPreemptive(), m.NewSelectionFIFO()
-	case "rr1":
-		return m.NewRoundRobinEvictor(1), m.NewSelectionFIFO()
-	case "rr4":
-		return m.NewRoundRobinEvictor(4), m.NewSelectionFIFO()
-	case "rr":
-		return m.NewRoundRobinEvictor(*roundRobinQuantum), m.NewSelectionFIFO()
-	case "spn":
-		return m.NewNonPreemptive(), m.NewSelectionSPN()
-	case "srt":
-		srt := m.NewSchedulerSRT(procQueue, cpuCount)
-		return srt, srt
-	case "hrrn":
-		return m.NewNonPreemptive(), m.NewSelectionHRRN()
+func formatState(state m.DumpState) string {
+	return fmt.Sprintf("%3s %s %s", state.Tick, strings.Join(state.CpusState, " "), strings.Join(state.IoStates, " "))
+}
+
+func runSweep(config sim.Config, workload sim.Workload) {
+	points, err := sim.Sweep(context.Background(), config, workload, sweepParams)
+	if err != nil {
+		panic(err)
+	}
+	grid := report.SweepRows(sweepParams, points)
+	best := report.BestSweepRows(sweepParams, points)
+
+	writeCSV(*sweepOutput, grid)
+	writeTSV(os.Stdout, best)
+
+	if *exportXlsx != "" {
+		sheet := *schedAlgo + "_sweep"
+		f := xlsx.GetF(*exportXlsx, sheet)
+		xlsx.PrintSheet(f, sheet, grid)
+		xlsx.PrintTable(f, sheet, best, 0, len(grid)+2)
+		xlsx.SaveReport(f, *exportXlsx)
+	}
+}
+
+func runMonteCarlo(config sim.Config) {
+	spec, err := sim.ParseWorkloadSpec(*workloadSpec)
+	if err != nil {
+		panic(err)
+	}
+	algos := make([]string, 0)
+	if *compareAlgos == "" {
+		for _, algo := range sim.Algorithms() {
+			algos = append(algos, algo.Name)
+		}
+	} else {
+		for _, name := range strings.Split(*compareAlgos, ",") {
+			algos = append(algos, strings.TrimSpace(name))
+		}
+	}
+	mc := sim.MonteCarloConfig{Runs: *monteCarloRuns, Seed: *seed, Spec: spec, Algorithms: algos}
+	result, err := sim.MonteCarlo(context.Background(), config, mc)
+	if err != nil {
+		panic(err)
+	}
+	info := [][]string{
+		{"Workloads", strconv.Itoa(mc.Runs)},
+		{"Seed", strconv.FormatInt(mc.Seed, 10)},
+		{"Workload spec", spec.String()},
+	}
+	estimates := report.EstimateRows(result)
+	winRates := report.WinRateRows(result)
+
+	writeCSV(*monteCarloOutput, estimates)
+	writeCSV(suffixedFileName(*monteCarloOutput, "_winrates"), winRates)
+	writeTSV(os.Stdout, info)
+	fmt.Println()
+	writeTSV(os.Stdout, estimates)
+
+	if *exportXlsx != "" {
+		sheet := "montecarlo"
+		f := xlsx.GetF(*exportXlsx, sheet)
+		xlsx.PrintSheet(f, sheet, info)
+		xlsx.PrintTable(f, sheet, estimates, 0, len(info)+2)
+		xlsx.PrintTable(f, sheet, winRates, 0, len(info)+len(estimates)+3)
+		xlsx.SaveReport(f, *exportXlsx)
+	}
+}
+
+func ganttChart(result sim.Result) gantt.Chart {
+	switch *ganttBy {
+	case "resource":
+		return gantt.ByResource(result.Header, result.Timeline)
+	case "process":
+		return gantt.ByProcess(result.Header, result.Timeline)
 	default:
-		panic(fmt.Sprintf("Unknown scheduling algorithm %s", schedAlgo))
+		panic(fmt.Sprintf("Unknown -gantt-by %s, expected resource or process", *ganttBy))
+	}
+}
+
+func writeGantt(result sim.Result) {
+	write := func(w io.Writer) error {
+		return gantt.WriteASCII(w, ganttChart(result), gantt.ASCIIOptions{Width: *ganttWidth})
+	}
+	if *ganttFile != "-" {
+		writeFile(*ganttFile, write)
+	} else if err := write(os.Stdout); err != nil {
+		panic(err)
+	}
+}
+
+func writeSvg(result sim.Result) {
+	writeFile(*exportSvg, func(w io.Writer) error {
+		return gantt.WriteSVG(w, ganttChart(result), result.Events, gantt.SVGOptions{Title: result.Title()})
+	})
+}
+
+func writeLatex(result sim.Result) {
+	writeFile(*exportLatex, func(w io.Writer) error {
+		return report.WriteLaTeX(w, result, ganttChart(result))
+	})
+}
+
+func writeMarkdown(result sim.Result) {
+	writeFile(*exportMarkdown, func(w io.Writer) error {
+		return report.WriteMarkdown(w, result, ganttChart(result), gantt.ASCIIOptions{Width: *ganttWidth})
+	})
+}
+
+func writeMermaid(result sim.Result) {
+	opts := gantt.MermaidOptions{
+		Title:  result.Title(),
+		Fenced: strings.HasSuffix(*exportMermaid, ".md"),
+	}
+	writeFile(*exportMermaid, func(w io.Writer) error {
+		return gantt.WriteMermaid(w, ganttChart(result), result.Tasks, opts)
+	})
+}
+
+// writeTSV - one line per row with tab separated values
+func writeTSV(w io.Writer, rows [][]string) {
+	for _, row := range rows {
+		fmt.Fprintln(w, strings.Join(row, "\t"))
+	}
+}
+
+// writeHtml - report of result and of every algorithm of -algos run with the same config
+func writeHtml(result sim.Result, workload sim.Workload) {
+	results := []sim.Result{result}
+	if *compareAlgos != "" {
+		for _, name := range strings.Split(*compareAlgos, ",") {
+			name = strings.TrimSpace(name)
+			if name == result.Config.Algorithm {
+				continue
+			}
+			config := result.Config
+			config.Algorithm = name
+			config.LogOutput = nil
+			res, err := sim.Run(context.Background(), config, workload)
+			if err != nil {
+				panic(err)
+			}
+			results = append(results, res)
+		}
+	}
+	title := fmt.Sprintf("Scheduling report, %d CPUs, %d IO devices, %d processes", result.Config.CPUs, result.Config.Devices, len(result.Procs))
+	writeFile(*exportHtml, func(w io.Writer) error {
+		return report.WriteHTML(w, title, results)
+	})
+}
+
+func writeTrace(result sim.Result) {
+	writeFile(*exportTrace, func(w io.Writer) error {
+		return trace.WriteChromeTrace(w, result)
+	})
+}
+
+func writeEvents(result sim.Result) {
+	writeFile(*eventsFile, func(w io.Writer) error {
+		return trace.WriteEvents(w, result.Events)
+	})
+}
+
+func writeCSV(fileName string, rows [][]string) {
+	writeFile(fileName, func(w io.Writer) error {
+		return report.WriteCSV(w, rows)
+	})
+}
+
+// writeFile - creates fileName and fills it with write
+func writeFile(fileName string, write func(io.Writer) error) {
+	f, err := os.Create(fileName)
+	if err != nil {
+		panic(err)
 	}
+	defer f.Close()
+	if err := write(f); err != nil {
+		panic(err)
+	}
+}
+
+// suffixedFileName - fileName with suffix inserted before the extension, out.csv -> out_suffix.csv
+func suffixedFileName(fileName string, suffix string) string {
+	ext := filepath.Ext(fileName)
+	return strings.TrimSuffix(fileName, ext) + suffix + ext
+}
+
+func printRunInfo(w io.Writer, info [][]string) {
+	fmt.Fprintln(w)
+	writeTSV(w, info)
 }
 
 func parseLogLevel(level string) slog.Level {
@@ -132,6 +342,47 @@ func parseLogLevel(level string) slog.Level {
 
 func main() {
 	flag.Parse()
+	if *schedAlgo == "help" {
+		printAlgorithms(os.Stdout)
+		return
+	}
+	params := sim.Params{"quantum": strconv.Itoa(*roundRobinQuantum)}
+	if *preemptExpr != "" && *selectExpr == "" {
+		panic("-preempt can't be used without -select")
+	}
+	if *selectExpr != "" {
+		if isFlagSet("algo") && *schedAlgo != "expr" {
+			panic(fmt.Sprintf("-select can't be used with -algo %s", *schedAlgo))
+		}
+		*schedAlgo = "expr"
+		params["select"] = *selectExpr
+		params["preempt"] = *preemptExpr
+	}
+	for name, value := range algoParams {
+		params[name] = value
+	}
+	tieBreakPolicy, err := m.ParseTieBreak(*tieBreak)
+	if err != nil {
+		panic(err)
+	}
+	config := sim.Config{
+		CPUs:      *cpuCount,
+		Devices:   *deviceCount,
+		Algorithm: *schedAlgo,
+		Params:    params,
+		Arrival:   sim.NewFixedIntervalArrival(*arrivalInterval),
+		TieBreak:  tieBreakPolicy,
+		LogOutput: os.Stdout,
+		LogLevel:  parseLogLevel(*logLevel),
+
+		SwitchCost:          *switchCost,
+		StarvationThreshold: *starvation,
+	}
+	if *monteCarloRuns > 0 {
+		runMonteCarlo(config)
+		return
+	}
+
 	var input io.Reader
 
 	if *inputFile != "" {
@@ -145,56 +396,61 @@ func main() {
 		input = os.Stdin
 	}
 
-	var output io.Writer
-
-	output, err := os.Create(*outputFile)
+	workload, err := sim.ParseWorkload(input)
 	if err != nil {
 		panic(err)
 	}
 
-	defer output.(*os.File).Close()
-	snapshotFunc := func(state m.DumpState) {
-		snapshotState(output, fmt.Sprintf("%3s %s %s %s", state.Tick, strings.Join(state.CpusState, " "), state.Io1State, state.Io2State))
+	if len(sweepParams) > 0 {
+		runSweep(config, workload)
+		return
 	}
-	var f *excelize.File
-	if *exportXlsx != "" {
-		f = xlsx.GetF(*exportXlsx, *schedAlgo)
-		colors := xlsx.GenerateStyles(f)
-		snapshotFunc = func(state m.DumpState) {
-			snapshotState(output, fmt.Sprintf("%3s %s %s %s", state.Tick, strings.Join(state.CpusState, " "), state.Io1State, state.Io2State))
-			xlsx.SnapshotStateXlsx(f, *schedAlgo, state.Tick, state.CpusState, state.Io1State, state.Io2State, colors, *cpuCount)
-		}
+	result, err := sim.Run(context.Background(), config, workload)
+	if err != nil {
+		panic(err)
 	}
 
-	clock := &m.Clock{CurrentTick: 0}
-
-	logLevel := parseLogLevel(*logLevel)
-	defaultHandler := slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: logLevel})
-	logger := slog.New(log.NewTickLoggerHandler(defaultHandler, clock))
-	processes := ParseProcesses(input, logger, clock)
-
-	logger.Info(fmt.Sprintf("Running with %d CPUs", *cpuCount))
-	logger.Info(fmt.Sprintf("Total processes: %d", len(processes)))
-
-	// IO is always fcfs
-	fcfs := m.NewNonPreemptive()
-
-	fifoSelection := m.NewSelectionFIFO()
-
-	cpuProcQueue := m.NewProcQueue("CPUs", clock)
-	evictor, selectionFunc := getScheduler(*schedAlgo, cpuProcQueue, *cpuCount)
-
-	io1ProcQueue := m.NewProcQueue("IO1", clock)
-	io2ProcQueue := m.NewProcQueue("IO2", clock)
-
-	io1Scheduler := m.NewSchedulerWrapper("IO1", io2ProcQueue, fifoSelection, fcfs, m.NewResource("IO1", m.IO1), clock, logger)
-	io2Scheduler := m.NewSchedulerWrapper("IO2", io1ProcQueue, fifoSelection, fcfs, m.NewResource("IO2", m.IO2), clock, logger)
-	cpuScheduler := m.NewSchedulerWrapper("CPUs", cpuProcQueue, selectionFunc, evictor, m.NewCpuPool(*cpuCount), clock, logger)
+	output, err := os.Create(*outputFile)
+	if err != nil {
+		panic(err)
+	}
+	defer output.Close()
 
-	// Run scheduler
-	machine := m.NewMachine(cpuScheduler, io1Scheduler, io2Scheduler, clock, logger, snapshotFunc, *cpuCount)
+	snapshotState(output, formatState(result.Header))
+	for _, state := range result.Timeline {
+		snapshotState(output, formatState(state))
+	}
 
-	machine.Run(processes)
+	if *ganttFile != "" {
+		writeGantt(result)
+	}
+	if *exportSvg != "" {
+		writeSvg(result)
+	}
+	if *exportHtml != "" {
+		writeHtml(result, workload)
+	}
+	if *exportTrace != "" {
+		writeTrace(result)
+	}
+	if *eventsFile != "" {
+		writeEvents(result)
+	}
+	if *exportLatex != "" {
+		writeLatex(result)
+	}
+	if *exportMarkdown != "" {
+		writeMarkdown(result)
+	}
+	if *exportMermaid != "" {
+		writeMermaid(result)
+	}
+	if *csvTimeline != "" {
+		writeCSV(*csvTimeline, report.TimelineRows(result.Header, result.Timeline))
+	}
+	if *csvStats != "" {
+		writeCSV(*csvStats, report.ProcStatsRows(result.Procs))
+	}
 
 	procStatsFile, err := os.Create(*procStatsFile)
 	if err != nil {
@@ -202,9 +458,42 @@ func main() {
 	}
 
 	defer procStatsFile.Close()
-	printProcsStats(procStatsFile, processes)
+	writeTSV(procStatsFile, report.ProcStatsRows(result.Procs))
+	if *taskStatsFile != "" {
+		taskStatsFile, err := os.Create(*taskStatsFile)
+		if err != nil {
+			panic(err)
+		}
+		defer taskStatsFile.Close()
+		writeTSV(taskStatsFile, report.TaskStatsRows(result.Tasks))
+	}
+
+	queues := report.QueueRows(result.Header, result.Timeline)
+	if *queuesFile != "" {
+		queuesFile, err := os.Create(*queuesFile)
+		if err != nil {
+			panic(err)
+		}
+		defer queuesFile.Close()
+		writeTSV(queuesFile, queues)
+	}
+
+	summary := append(report.RunInfo(result.Config), report.MetricsRows(result.Metrics)...)
+	printRunInfo(procStatsFile, summary)
+	resources := report.ResourceRows(result.Metrics)
+	printRunInfo(procStatsFile, resources)
 	if *exportXlsx != "" {
-		xlsx.PrintProcsStats(f, *schedAlgo, processes, 1+*cpuCount+2+1)
+		f := xlsx.GetF(*exportXlsx, *schedAlgo)
+		styles := xlsx.GenerateStyles(f, len(result.Procs))
+		xlsx.PrintTimeline(f, *schedAlgo, result.Header, result.Timeline, styles)
+		xlsx.PrintGantt(f, *schedAlgo+"_gantt", result.Header, result.Timeline, styles)
+		statsOffset := 1 + *cpuCount + *deviceCount + 1
+		summaryRow := xlsx.PrintProcsStats(f, *schedAlgo, result.Procs, result.Header, len(result.Timeline), statsOffset) + 1
+		xlsx.PrintTable(f, *schedAlgo, summary, statsOffset, summaryRow)
+		xlsx.PrintTable(f, *schedAlgo, resources, statsOffset, summaryRow+len(summary)+1)
+		xlsx.PrintTaskStats(f, *schedAlgo+"_bursts", result.Tasks)
+		xlsx.PrintSheet(f, *schedAlgo+"_queues", queues)
+		xlsx.PrintSummary(f, *schedAlgo, result)
 		xlsx.SaveReport(f, *exportXlsx)
 	}
 }
@@ -0,0 +1,116 @@
+package gantt
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+const (
+	idleSymbol  = '.'
+	axisStep    = 10
+	symbolChars = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+)
+
+// ASCIIOptions - Width limits columns of the chart, ticks are compressed to fit. 0 means no limit
+type ASCIIOptions struct {
+	Width int
+}
+
+// WriteASCII - one line per row with a symbol per column, followed by tick axis and legend.
+// When a column covers several ticks it shows the busy label which took most of them, idle only if all were idle
+func WriteASCII(w io.Writer, chart Chart, opts ASCIIOptions) error {
+	scale := 1
+	if opts.Width > 0 && chart.Ticks > opts.Width {
+		scale = (chart.Ticks + opts.Width - 1) / opts.Width
+	}
+	columns := (chart.Ticks + scale - 1) / scale
+	symbols := asciiSymbols(chart.Labels)
+
+	nameWidth := 0
+	for _, r := range chart.Rows {
+		nameWidth = max(nameWidth, len(r.Name))
+	}
+
+	var sb strings.Builder
+	sb.WriteString(strings.Repeat(" ", nameWidth+2))
+	sb.WriteString(asciiAxis(columns, scale))
+	sb.WriteString("\n")
+	for _, r := range chart.Rows {
+		fmt.Fprintf(&sb, "%-*s |", nameWidth, r.Name)
+		for col := 0; col < columns; col++ {
+			label := dominantLabel(r.Cells[col*scale : min((col+1)*scale, chart.Ticks)])
+			if label == idle {
+				sb.WriteRune(idleSymbol)
+			} else {
+				sb.WriteRune(symbols[label])
+			}
+		}
+		sb.WriteString("|\n")
+	}
+
+	sb.WriteString("\n")
+	if scale > 1 {
+		fmt.Fprintf(&sb, "1 column = %d ticks, showing the label which occupied most busy ticks\n", scale)
+	}
+	fmt.Fprintf(&sb, "%c idle\n", idleSymbol)
+	for _, label := range chart.Labels {
+		fmt.Fprintf(&sb, "%c %s %s\n", symbols[label], chart.LabelKind, label)
+	}
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// asciiAxis - tick of every axisStep-th column above the chart
+func asciiAxis(columns int, scale int) string {
+	axis := []byte(strings.Repeat(" ", columns+axisStep))
+	for col := 0; col < columns; col += axisStep {
+		copy(axis[col:], fmt.Sprintf("%d", col*scale))
+	}
+	return strings.TrimRight(string(axis), " ")
+}
+
+// asciiSymbols - single character labels like process ids stay as they are, longer ones get letters
+func asciiSymbols(labels []string) map[string]rune {
+	symbols := make(map[string]rune, len(labels))
+	used := make(map[rune]bool)
+	for _, label := range labels {
+		if runes := []rune(label); len(runes) == 1 {
+			symbols[label] = runes[0]
+			used[runes[0]] = true
+		}
+	}
+	next := 0
+	for _, label := range labels {
+		if _, ok := symbols[label]; ok {
+			continue
+		}
+		for next < len(symbolChars) && used[rune(symbolChars[next])] {
+			next++
+		}
+		symbol := '?'
+		if next < len(symbolChars) {
+			symbol = rune(symbolChars[next])
+			next++
+		}
+		symbols[label] = symbol
+	}
+	return symbols
+}
+
+// dominantLabel - most frequent busy label of cells, the earliest one wins ties. Idle only when all cells are idle
+func dominantLabel(cells []string) string {
+	counts := make(map[string]int)
+	for _, label := range cells {
+		if label != idle {
+			counts[label]++
+		}
+	}
+	best := idle
+	for _, label := range cells {
+		if counts[label] > counts[best] {
+			best = label
+		}
+	}
+	return best
+}
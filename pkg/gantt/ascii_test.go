@@ -0,0 +1,65 @@
+package gantt
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDominantLabel(t *testing.T) {
+	tests := []struct {
+		cells []string
+		want  string
+	}{
+		{[]string{"1", "1", "2"}, "1"},
+		{[]string{"1", idle, idle}, "1"},
+		{[]string{idle, idle, "2", idle}, "2"},
+		{[]string{"1", "2", "2", idle, idle, idle}, "2"},
+		{[]string{"2", "1", "1", "2"}, "2"},
+		{[]string{idle, idle}, idle},
+		{[]string{}, idle},
+	}
+	for _, tt := range tests {
+		if got := dominantLabel(tt.cells); got != tt.want {
+			t.Errorf("dominantLabel(%q) = %q, want %q", tt.cells, got, tt.want)
+		}
+	}
+}
+
+func TestWriteASCIICompressed(t *testing.T) {
+	chart := Chart{
+		Ticks: 7,
+		Rows: []Row{
+			{Name: "CPU1", Cells: []string{"1", idle, idle, "2", "2", idle, "1"}},
+			{Name: "IO1", Cells: []string{idle, idle, idle, idle, idle, "1", idle}},
+			{Name: "IO2", Cells: []string{idle, idle, idle, idle, idle, idle, idle}},
+		},
+		Labels:    []string{"1", "2"},
+		LabelKind: "process",
+	}
+	want := `      0
+CPU1 |121|
+IO1  |.1.|
+IO2  |...|
+
+1 column = 3 ticks, showing the label which occupied most busy ticks
+. idle
+1 process 1
+2 process 2
+`
+	var sb strings.Builder
+	if err := WriteASCII(&sb, chart, ASCIIOptions{Width: 3}); err != nil {
+		t.Fatal(err)
+	}
+	if sb.String() != want {
+		t.Errorf("got\n%s\nwant\n%s", sb.String(), want)
+	}
+
+	// without compression every tick has its own column
+	sb.Reset()
+	if err := WriteASCII(&sb, chart, ASCIIOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Split(sb.String(), "\n")[1]; got != "CPU1 |1..22.1|" {
+		t.Errorf("uncompressed row %q", got)
+	}
+}
@@ -0,0 +1,110 @@
+// Package gantt turns simulation timeline into Gantt charts
+package gantt
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	m "github.com/Moleus/os-solver/pkg/machine"
+)
+
+const idle = ""
+
+// Row - one line of a chart, Cells holds label of every tick, empty when idle
+type Row struct {
+	Name  string
+	Cells []string
+}
+
+// Segment - label occupying ticks Start..End-1 of a row
+type Segment struct {
+	Label string
+	Start int
+	End   int
+}
+
+// Segments - runs of equal non idle labels
+func (r Row) Segments() []Segment {
+	segments := make([]Segment, 0)
+	for tick, label := range r.Cells {
+		if label == idle {
+			continue
+		}
+		if n := len(segments); n > 0 && segments[n-1].Label == label && segments[n-1].End == tick {
+			segments[n-1].End++
+			continue
+		}
+		segments = append(segments, Segment{Label: label, Start: tick, End: tick + 1})
+	}
+	return segments
+}
+
+type Chart struct {
+	Ticks int
+	Rows  []Row
+	// Labels - every label used in cells, in legend order
+	Labels []string
+	// LabelKind - what labels stand for: process or resource
+	LabelKind string
+}
+
+// ByResource - row per CPU and IO device, labels are process ids
+func ByResource(header m.DumpState, timeline []m.DumpState) Chart {
+	names := append(append([]string{}, header.CpusState...), header.IoStates...)
+	rows := make([]Row, len(names))
+	for i, name := range names {
+		rows[i] = Row{Name: name, Cells: make([]string, len(timeline))}
+	}
+	procs := 0
+	for tick, state := range timeline {
+		for i, proc := range append(append([]string{}, state.CpusState...), state.IoStates...) {
+			if proc != "-" {
+				rows[i].Cells[tick] = proc
+			}
+			if id, err := strconv.Atoi(proc); err == nil {
+				procs = max(procs, id)
+			}
+		}
+	}
+	labels := make([]string, procs)
+	for i := range labels {
+		labels[i] = strconv.Itoa(i + 1)
+	}
+	return Chart{Ticks: len(timeline), Rows: rows, Labels: labels, LabelKind: "process"}
+}
+
+// ByProcess - row per process, labels are resources the process occupied
+func ByProcess(header m.DumpState, timeline []m.DumpState) Chart {
+	resources := ByResource(header, timeline)
+	rows := make([]Row, len(resources.Labels))
+	for i := range rows {
+		rows[i] = Row{Name: fmt.Sprintf("P%d", i+1), Cells: make([]string, len(timeline))}
+	}
+	for _, r := range resources.Rows {
+		for tick, label := range r.Cells {
+			if id, err := strconv.Atoi(label); err == nil {
+				rows[id-1].Cells[tick] = r.Name
+			}
+		}
+	}
+	names := make([]string, len(resources.Rows))
+	for i, r := range resources.Rows {
+		names[i] = r.Name
+	}
+	return Chart{Ticks: len(timeline), Rows: rows, Labels: names, LabelKind: "resource"}
+}
+
+// BurstAt - index of burst of process id (one based) which occupied resource at tick
+func BurstAt(tasks []m.TaskStats, id int, resource string, tick int) (int, bool) {
+	for _, t := range tasks {
+		if t.ProcId != id-1 || t.StartTime == -1 || t.StartTime > tick || t.FinishTime < tick {
+			continue
+		}
+		// CPU bursts run on any of CPU1..N, IO bursts on the device named after their type
+		if t.ResourceType.String() == resource || (t.ResourceType == m.CPU && strings.HasPrefix(resource, "CPU")) {
+			return t.TaskIndex, true
+		}
+	}
+	return 0, false
+}
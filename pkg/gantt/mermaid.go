@@ -0,0 +1,62 @@
+package gantt
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	m "github.com/Moleus/os-solver/pkg/machine"
+)
+
+// MermaidOptions - Title of the diagram, Fenced wraps it into a ```mermaid block for Markdown
+type MermaidOptions struct {
+	Title  string
+	Fenced bool
+}
+
+// WriteMermaid - gantt diagram with a section per chart row and a task per uninterrupted run of a burst.
+// Ticks are written as unix seconds, so the axis shows tick numbers
+func WriteMermaid(w io.Writer, chart Chart, tasks []m.TaskStats, opts MermaidOptions) error {
+	var sb strings.Builder
+	if opts.Fenced {
+		sb.WriteString("```mermaid\n")
+	}
+	sb.WriteString("gantt\n")
+	if opts.Title != "" {
+		fmt.Fprintf(&sb, "    title %s\n", mermaidText(opts.Title))
+	}
+	sb.WriteString("    dateFormat X\n    axisFormat %s\n")
+	for _, r := range chart.Rows {
+		fmt.Fprintf(&sb, "    section %s\n", mermaidText(r.Name))
+		for _, seg := range r.Segments() {
+			fmt.Fprintf(&sb, "    %s : %d, %d\n", mermaidText(mermaidTaskName(chart, r, seg, tasks)), seg.Start, seg.End)
+		}
+	}
+	if opts.Fenced {
+		sb.WriteString("```\n")
+	}
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// mermaidTaskName - process and burst on resource rows, resource and burst on process rows
+func mermaidTaskName(chart Chart, r Row, seg Segment, tasks []m.TaskStats) string {
+	name, resource, procLabel := barText(chart, seg.Label), r.Name, seg.Label
+	if chart.LabelKind != "process" {
+		resource, procLabel = seg.Label, strings.TrimPrefix(r.Name, "P")
+	}
+	id, err := strconv.Atoi(procLabel)
+	if err != nil {
+		return name
+	}
+	if burst, ok := BurstAt(tasks, id, resource, seg.Start); ok {
+		name = fmt.Sprintf("%s burst %d", name, burst+1)
+	}
+	return name
+}
+
+// mermaidText - colons and semicolons separate task fields and statements
+func mermaidText(s string) string {
+	return strings.NewReplacer(":", " ", ";", " ", "#", " ").Replace(s)
+}
@@ -0,0 +1,79 @@
+package gantt
+
+import (
+	"strings"
+	"testing"
+
+	m "github.com/Moleus/os-solver/pkg/machine"
+)
+
+// P1 runs its CPU burst on ticks 0-1 and 3-4 around P2, then its IO burst on ticks 5-6
+var mermaidTasks = []m.TaskStats{
+	{ProcId: 0, TaskIndex: 0, ResourceType: m.CPU, StartTime: 0, FinishTime: 4},
+	{ProcId: 0, TaskIndex: 1, ResourceType: m.IO1, StartTime: 5, FinishTime: 6},
+	{ProcId: 1, TaskIndex: 0, ResourceType: m.CPU, StartTime: 2, FinishTime: 2},
+}
+
+func TestWriteMermaid(t *testing.T) {
+	tests := []struct {
+		chart Chart
+		opts  MermaidOptions
+		want  string
+	}{
+		{
+			chart: Chart{
+				Ticks: 7,
+				Rows: []Row{
+					{Name: "CPU1", Cells: []string{"1", "1", "2", "1", "1", idle, idle}},
+					{Name: "IO1", Cells: []string{idle, idle, idle, idle, idle, "1", "1"}},
+				},
+				Labels:    []string{"1", "2"},
+				LabelKind: "process",
+			},
+			opts: MermaidOptions{Title: "rr: 1 CPU; quantum 2"},
+			want: `gantt
+    title rr  1 CPU  quantum 2
+    dateFormat X
+    axisFormat %s
+    section CPU1
+    P1 burst 1 : 0, 2
+    P2 burst 1 : 2, 3
+    P1 burst 1 : 3, 5
+    section IO1
+    P1 burst 2 : 5, 7
+`,
+		},
+		{
+			chart: Chart{
+				Ticks: 7,
+				Rows: []Row{
+					{Name: "P1", Cells: []string{"CPU1", "CPU1", idle, "CPU1", "CPU1", "IO1", "IO1"}},
+					{Name: "P2", Cells: []string{idle, idle, "CPU1", idle, idle, idle, idle}},
+				},
+				Labels:    []string{"CPU1", "IO1"},
+				LabelKind: "resource",
+			},
+			opts: MermaidOptions{Fenced: true},
+			want: "```mermaid" + `
+gantt
+    dateFormat X
+    axisFormat %s
+    section P1
+    CPU1 burst 1 : 0, 2
+    CPU1 burst 1 : 3, 5
+    IO1 burst 2 : 5, 7
+    section P2
+    CPU1 burst 1 : 2, 3
+` + "```\n",
+		},
+	}
+	for _, tt := range tests {
+		var sb strings.Builder
+		if err := WriteMermaid(&sb, tt.chart, mermaidTasks, tt.opts); err != nil {
+			t.Fatal(err)
+		}
+		if sb.String() != tt.want {
+			t.Errorf("got\n%s\nwant\n%s", sb.String(), tt.want)
+		}
+	}
+}
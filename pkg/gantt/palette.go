@@ -0,0 +1,46 @@
+package gantt
+
+import (
+	"fmt"
+	"math"
+)
+
+// goldenAngle - hue step which keeps neighbouring colours far apart for any count
+const goldenAngle = 137.508
+
+// Palette - n distinct light colours as #rrggbb, the same for the same index across charts
+func Palette(n int) []string {
+	colors := make([]string, n)
+	for i := range colors {
+		hue := math.Mod(float64(i)*goldenAngle, 360)
+		// alternate lightness so that close hues after many steps still differ
+		lightness := 0.62 + 0.1*float64(i%2)
+		colors[i] = hslToHex(hue, 0.65, lightness)
+	}
+	return colors
+}
+
+func hslToHex(hue float64, saturation float64, lightness float64) string {
+	c := (1 - math.Abs(2*lightness-1)) * saturation
+	x := c * (1 - math.Abs(math.Mod(hue/60, 2)-1))
+	var r, g, b float64
+	switch {
+	case hue < 60:
+		r, g, b = c, x, 0
+	case hue < 120:
+		r, g, b = x, c, 0
+	case hue < 180:
+		r, g, b = 0, c, x
+	case hue < 240:
+		r, g, b = 0, x, c
+	case hue < 300:
+		r, g, b = x, 0, c
+	default:
+		r, g, b = c, 0, x
+	}
+	m := lightness - c/2
+	toByte := func(v float64) int {
+		return int(math.Round((v + m) * 255))
+	}
+	return fmt.Sprintf("#%02x%02x%02x", toByte(r), toByte(g), toByte(b))
+}
@@ -0,0 +1,217 @@
+package gantt
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"strings"
+
+	m "github.com/Moleus/os-solver/pkg/machine"
+)
+
+const (
+	svgRowHeight   = 24
+	svgBarPadding  = 3
+	svgTopMargin   = 30
+	svgAxisHeight  = 24
+	svgLegendRow   = 20
+	svgMaxWidth    = 1600
+	svgMinTickPx   = 2
+	svgMaxTickPx   = 24
+	svgMinAxisStep = 40
+	eventsRowName  = "Events"
+)
+
+// SVGOptions - Title is drawn above the chart. TickWidth is pixels per tick, picked from chart length if 0
+type SVGOptions struct {
+	Title     string
+	TickWidth int
+}
+
+// WriteSVG - bars coloured per label, time axis and legend. Preemptions are marked on the row of the
+// preempted resource or process, arrivals and finishes on process rows or on a separate events row
+func WriteSVG(w io.Writer, chart Chart, events []m.Event, opts SVGOptions) error {
+	l := newSVGLayout(chart, opts)
+	colors := labelColors(chart)
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" font-family="sans-serif" font-size="12">`+"\n",
+		l.width, l.height, l.width, l.height)
+	fmt.Fprintf(&sb, `<rect width="100%%" height="100%%" fill="white"/>`+"\n")
+	if opts.Title != "" {
+		fmt.Fprintf(&sb, `<text x="%d" y="20" font-size="14" font-weight="bold">%s</text>`+"\n", l.left, html.EscapeString(opts.Title))
+	}
+
+	for i, name := range l.rowNames {
+		y := l.rowY(i)
+		fmt.Fprintf(&sb, `<text x="%d" y="%d" text-anchor="end" dominant-baseline="middle">%s</text>`+"\n", l.left-6, y+svgRowHeight/2, html.EscapeString(name))
+		fmt.Fprintf(&sb, `<line x1="%d" y1="%d" x2="%d" y2="%d" stroke="#e0e0e0"/>`+"\n", l.left, y+svgRowHeight, l.x(chart.Ticks), y+svgRowHeight)
+	}
+
+	// grid and axis
+	axisY := l.rowY(len(l.rowNames))
+	for tick := 0; tick <= chart.Ticks; tick += l.axisStep {
+		x := l.x(tick)
+		fmt.Fprintf(&sb, `<line x1="%d" y1="%d" x2="%d" y2="%d" stroke="#f0f0f0"/>`+"\n", x, svgTopMargin, x, axisY)
+		fmt.Fprintf(&sb, `<line x1="%d" y1="%d" x2="%d" y2="%d" stroke="black"/>`+"\n", x, axisY, x, axisY+4)
+		fmt.Fprintf(&sb, `<text x="%d" y="%d" text-anchor="middle">%d</text>`+"\n", x, axisY+16, tick)
+	}
+	fmt.Fprintf(&sb, `<line x1="%d" y1="%d" x2="%d" y2="%d" stroke="black"/>`+"\n", l.left, axisY, l.x(chart.Ticks), axisY)
+
+	for i, r := range chart.Rows {
+		y := l.rowY(i) + svgBarPadding
+		for _, seg := range r.Segments() {
+			x, width := l.x(seg.Start), l.x(seg.End)-l.x(seg.Start)
+			fmt.Fprintf(&sb, `<rect x="%d" y="%d" width="%d" height="%d" fill="%s" stroke="#555" stroke-width="0.5"><title>%s</title></rect>`+"\n",
+				x, y, width, svgRowHeight-2*svgBarPadding, colors[seg.Label], html.EscapeString(segmentTitle(chart, r, seg)))
+			if text := barText(chart, seg.Label); width >= 8*len(text) {
+				fmt.Fprintf(&sb, `<text x="%d" y="%d" text-anchor="middle" dominant-baseline="middle" pointer-events="none">%s</text>`+"\n",
+					x+width/2, y+svgRowHeight/2-svgBarPadding, html.EscapeString(text))
+			}
+		}
+	}
+
+	for _, e := range events {
+		row, ok := l.markerRow(chart, e)
+		if !ok {
+			continue
+		}
+		x, y := l.x(e.Tick), l.rowY(row)
+		switch e.Type {
+		case m.EventPreempt:
+			fmt.Fprintf(&sb, `<path d="M%d %d l-4 -6 h8 z" fill="#d62728"><title>P%d preempted from %s at %d</title></path>`+"\n", x, y+svgBarPadding+6, e.ProcId+1, e.Resource, e.Tick)
+		case m.EventArrival:
+			fmt.Fprintf(&sb, `<path d="M%d %d l-5 -8 h10 z" fill="#2ca02c" stroke="white" stroke-width="0.5"><title>P%d arrived at %d</title></path>`+"\n", x, y+svgRowHeight/2+4, e.ProcId+1, e.Tick)
+		case m.EventTerminate:
+			fmt.Fprintf(&sb, `<rect x="%d" y="%d" width="8" height="8" transform="rotate(45 %d %d)" fill="black"><title>P%d finished at %d</title></rect>`+"\n", x-4, y+svgRowHeight/2-4, x, y+svgRowHeight/2, e.ProcId+1, e.Tick)
+		}
+	}
+
+	legendY := axisY + svgAxisHeight + 10
+	x := l.left
+	for _, label := range chart.Labels {
+		text := legendText(chart, label)
+		fmt.Fprintf(&sb, `<rect x="%d" y="%d" width="12" height="12" fill="%s" stroke="#555" stroke-width="0.5"/>`+"\n", x, legendY, colors[label])
+		fmt.Fprintf(&sb, `<text x="%d" y="%d">%s</text>`+"\n", x+16, legendY+10, html.EscapeString(text))
+		x += 24 + 7*len(text)
+	}
+	legendY += svgLegendRow
+	fmt.Fprintf(&sb, `<path d="M%d %d l-4 -6 h8 z" fill="#d62728"/><text x="%d" y="%d">preemption</text>`+"\n", l.left+6, legendY+9, l.left+16, legendY+10)
+	fmt.Fprintf(&sb, `<path d="M%d %d l-5 -8 h10 z" fill="#2ca02c"/><text x="%d" y="%d">arrival</text>`+"\n", l.left+106, legendY+10, l.left+116, legendY+10)
+	fmt.Fprintf(&sb, `<rect x="%d" y="%d" width="8" height="8" transform="rotate(45 %d %d)" fill="black"/><text x="%d" y="%d">finish</text>`+"\n", l.left+202, legendY+2, l.left+206, legendY+6, l.left+216, legendY+10)
+
+	sb.WriteString("</svg>\n")
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+type svgLayout struct {
+	rowNames  []string
+	left      int
+	tickWidth int
+	axisStep  int
+	width     int
+	height    int
+}
+
+func newSVGLayout(chart Chart, opts SVGOptions) svgLayout {
+	l := svgLayout{left: 10}
+	for _, r := range chart.Rows {
+		l.rowNames = append(l.rowNames, r.Name)
+	}
+	if chart.LabelKind == "process" {
+		l.rowNames = append(l.rowNames, eventsRowName)
+	}
+	for _, name := range l.rowNames {
+		l.left = max(l.left, 7*len(name)+16)
+	}
+	l.tickWidth = opts.TickWidth
+	if l.tickWidth <= 0 {
+		l.tickWidth = svgMaxTickPx
+		if chart.Ticks > 0 {
+			l.tickWidth = min(svgMaxTickPx, max(svgMinTickPx, svgMaxWidth/chart.Ticks))
+		}
+	}
+	l.axisStep = niceStep(svgMinAxisStep / l.tickWidth)
+	// markers legend takes about 270px, labels legend is a single line
+	labelsWidth := 0
+	for _, label := range chart.Labels {
+		labelsWidth += 24 + 7*len(legendText(chart, label))
+	}
+	l.width = l.left + max(chart.Ticks*l.tickWidth+30, labelsWidth, 270)
+	l.height = svgTopMargin + len(l.rowNames)*svgRowHeight + svgAxisHeight + 10 + 2*svgLegendRow + 10
+	return l
+}
+
+func (l svgLayout) x(tick int) int {
+	return l.left + tick*l.tickWidth
+}
+
+func (l svgLayout) rowY(row int) int {
+	return svgTopMargin + row*svgRowHeight
+}
+
+// markerRow - row of event marker, false if the event is not drawn
+func (l svgLayout) markerRow(chart Chart, e m.Event) (int, bool) {
+	switch {
+	case e.Type == m.EventPreempt && chart.LabelKind == "process":
+		return rowIndex(l.rowNames, e.Resource)
+	case e.Type == m.EventPreempt || e.Type == m.EventArrival || e.Type == m.EventTerminate:
+		if chart.LabelKind == "process" {
+			return rowIndex(l.rowNames, eventsRowName)
+		}
+		return rowIndex(l.rowNames, fmt.Sprintf("P%d", e.ProcId+1))
+	}
+	return 0, false
+}
+
+func rowIndex(names []string, name string) (int, bool) {
+	for i, n := range names {
+		if n == name {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// niceStep - smallest of 1, 2, 5, 10, 20, 50... not less than n
+func niceStep(n int) int {
+	for base := 1; ; base *= 10 {
+		for _, k := range []int{1, 2, 5} {
+			if k*base >= n {
+				return k * base
+			}
+		}
+	}
+}
+
+func labelColors(chart Chart) map[string]string {
+	palette := Palette(len(chart.Labels))
+	colors := make(map[string]string, len(chart.Labels))
+	for i, label := range chart.Labels {
+		colors[label] = palette[i]
+	}
+	return colors
+}
+
+// barText - process ids are shown as P1, resources by name
+func barText(chart Chart, label string) string {
+	if chart.LabelKind == "process" {
+		return "P" + label
+	}
+	return label
+}
+
+func legendText(chart Chart, label string) string {
+	if chart.LabelKind == "process" {
+		return "process " + label
+	}
+	return label
+}
+
+func segmentTitle(chart Chart, r Row, seg Segment) string {
+	if chart.LabelKind == "process" {
+		return fmt.Sprintf("P%s on %s, ticks %d-%d", seg.Label, r.Name, seg.Start, seg.End-1)
+	}
+	return fmt.Sprintf("%s on %s, ticks %d-%d", r.Name, seg.Label, seg.Start, seg.End-1)
+}
@@ -0,0 +1,78 @@
+package gantt
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+const (
+	tikzMaxWidth = 15.0 // cm, fits a4 text width
+	tikzMaxTick  = 0.5
+	tikzRow      = 0.6
+)
+
+// WriteTikZ - tikzpicture with a row per chart row, bars coloured per label, time axis and legend.
+// Needs only \usepackage{tikz}
+func WriteTikZ(w io.Writer, chart Chart) error {
+	tickWidth := tikzMaxTick
+	if chart.Ticks > 0 {
+		tickWidth = min(tikzMaxTick, tikzMaxWidth/float64(chart.Ticks))
+	}
+	// keep axis labels about a centimetre apart
+	step := niceStep(int(1/tickWidth) + 1)
+	palette := Palette(len(chart.Labels))
+	colors := make(map[string]string, len(chart.Labels))
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "\\begin{tikzpicture}[x=%.4fcm, y=%.1fcm, font=\\small]\n", tickWidth, tikzRow)
+	for i, label := range chart.Labels {
+		colors[label] = fmt.Sprintf("gantt%d", i+1)
+		fmt.Fprintf(&sb, "\\definecolor{%s}{HTML}{%s}\n", colors[label], strings.ToUpper(strings.TrimPrefix(palette[i], "#")))
+	}
+
+	for i, r := range chart.Rows {
+		y := -float64(i)
+		fmt.Fprintf(&sb, "\\node[anchor=east] at (0,%.1f) {%s};\n", y-0.5, EscapeLaTeX(r.Name))
+		for _, seg := range r.Segments() {
+			fmt.Fprintf(&sb, "\\filldraw[fill=%s, draw=black!60] (%d,%.1f) rectangle (%d,%.1f);\n", colors[seg.Label], seg.Start, y-0.9, seg.End, y-0.1)
+			if text := barText(chart, seg.Label); float64(seg.End-seg.Start)*tickWidth >= 0.25*float64(len(text)) {
+				fmt.Fprintf(&sb, "\\node[font=\\scriptsize] at (%.1f,%.1f) {%s};\n", float64(seg.Start+seg.End)/2, y-0.5, EscapeLaTeX(text))
+			}
+		}
+	}
+
+	axisY := -len(chart.Rows)
+	fmt.Fprintf(&sb, "\\draw (0,%d) -- (%d,%d);\n", axisY, chart.Ticks, axisY)
+	for tick := 0; tick <= chart.Ticks; tick += step {
+		fmt.Fprintf(&sb, "\\draw (%d,%d) -- ++(0,-0.15) node[below] {%d};\n", tick, axisY, tick)
+	}
+
+	// legend items are placed in centimetres, wrapped at the chart width
+	x, y := 0.0, float64(axisY)-1.3
+	for _, label := range chart.Labels {
+		text := legendText(chart, label)
+		width := 0.6 + 0.2*float64(len(text))
+		if x > 0 && x+width > tikzMaxWidth {
+			x, y = 0, y-0.8
+		}
+		fmt.Fprintf(&sb, "\\filldraw[fill=%s, draw=black!60] ([xshift=%.2fcm]0,%.1f) rectangle ++(0.3cm,0.3cm) node[right, yshift=-0.15cm] {%s};\n",
+			colors[label], x, y, EscapeLaTeX(text))
+		x += width
+	}
+	sb.WriteString("\\end{tikzpicture}\n")
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+var latexReplacer = strings.NewReplacer(
+	`\`, `\textbackslash{}`,
+	`&`, `\&`, `%`, `\%`, `$`, `\$`, `#`, `\#`, `_`, `\_`, `{`, `\{`, `}`, `\}`,
+	`~`, `\textasciitilde{}`, `^`, `\textasciicircum{}`,
+	"λ", `$\lambda$`,
+)
+
+// EscapeLaTeX - text with LaTeX special characters escaped
+func EscapeLaTeX(s string) string {
+	return latexReplacer.Replace(s)
+}
@@ -0,0 +1,24 @@
+package gantt
+
+import "testing"
+
+func TestEscapeLaTeX(t *testing.T) {
+	tests := []struct {
+		text string
+		want string
+	}{
+		{"rr, 2 CPUs", "rr, 2 CPUs"},
+		{"Tr/Ts", "Tr/Ts"},
+		{"CPU_1 & IO_2", `CPU\_1 \& IO\_2`},
+		{"50% of #1", `50\% of \#1`},
+		{"$x$ {y}", `\$x\$ \{y\}`},
+		{`a\b`, `a\textbackslash{}b`},
+		{"~^", `\textasciitilde{}\textasciicircum{}`},
+		{"λ, arrivals per tick", `$\lambda$, arrivals per tick`},
+	}
+	for _, tt := range tests {
+		if got := EscapeLaTeX(tt.text); got != tt.want {
+			t.Errorf("EscapeLaTeX(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"io"
 	"log/slog"
 	"sync"
 )
@@ -16,11 +17,14 @@ type TickLoggerHandler struct {
 	h     slog.Handler
 	b     *bytes.Buffer
 	m     *sync.Mutex
+	w     io.Writer
 	clock GlobalTimer
 }
 
-func NewTickLoggerHandler(h slog.Handler, clock GlobalTimer) *TickLoggerHandler {
-	return &TickLoggerHandler{h: h, b: &bytes.Buffer{}, m: &sync.Mutex{}, clock: clock}
+// NewTickLoggerHandler - writes records prefixed with the current tick of clock to w.
+// h is only consulted for the enabled level, so each simulation can have its own clock and output.
+func NewTickLoggerHandler(w io.Writer, h slog.Handler, clock GlobalTimer) *TickLoggerHandler {
+	return &TickLoggerHandler{h: h, b: &bytes.Buffer{}, m: &sync.Mutex{}, w: w, clock: clock}
 }
 
 func (h *TickLoggerHandler) Enabled(ctx context.Context, level slog.Level) bool {
@@ -28,24 +32,27 @@ func (h *TickLoggerHandler) Enabled(ctx context.Context, level slog.Level) bool
 }
 
 func (h *TickLoggerHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
-	return &TickLoggerHandler{h: h.h.WithAttrs(attrs), b: h.b, m: h.m}
+	return &TickLoggerHandler{h: h.h.WithAttrs(attrs), b: h.b, m: h.m, w: h.w, clock: h.clock}
 }
 
 func (h *TickLoggerHandler) WithGroup(name string) slog.Handler {
-	return &TickLoggerHandler{h: h.h.WithGroup(name), b: h.b, m: h.m}
+	return &TickLoggerHandler{h: h.h.WithGroup(name), b: h.b, m: h.m, w: h.w, clock: h.clock}
 }
 
 func (h *TickLoggerHandler) Handle(ctx context.Context, r slog.Record) error {
 	currentTick := fmt.Sprintf("t%d", h.clock.GetCurrentTick())
 
-	fmt.Println(
+	h.m.Lock()
+	defer h.m.Unlock()
+	_, err := fmt.Fprintln(
+		h.w,
 		r.Time.Format(timeFormat),
 		r.Level,
 		currentTick,
 		r.Message,
 	)
 
-	return nil
+	return err
 }
 
 type GlobalTimer interface {
@@ -9,10 +9,12 @@ import (
 type Scheduler interface {
 	CheckRunningProcs()
 	ProcessQueue()
-	PushToQueue(p *Process)
+	// PushToQueue - reason is reported with the enqueue event
+	PushToQueue(p *Process, reason string)
 	GetEvictedProcs() []*Process
 	ClearEvictedProcs()
 	GetResource() Resourcer
+	GetQueue() *ProcQueue
 }
 
 type SelectionFunction interface {
@@ -31,6 +33,11 @@ type Evictor interface {
 	ChooseToEvict(procs []*Process) []*Process
 }
 
+// EvictReasoner - optional interface of Evictor which explains why a running process was preempted
+type EvictReasoner interface {
+	EvictReason(p *Process) string
+}
+
 type SchedulerWrapper struct {
 	name string
 
@@ -43,20 +50,44 @@ type SchedulerWrapper struct {
 
 	selectionFunc SelectionFunction
 	evictor       Evictor
+
+	observer Observer
 }
 
-func NewSchedulerWrapper(name string, queue *ProcQueue, selection SelectionFunction, evictor Evictor, r Resourcer, clock log.GlobalTimer, logger *slog.Logger) *SchedulerWrapper {
+func NewSchedulerWrapper(name string, queue *ProcQueue, selection SelectionFunction, evictor Evictor, r Resourcer, clock log.GlobalTimer, logger *slog.Logger, observer Observer) *SchedulerWrapper {
 	evictedProcs := make([]*Process, 0)
-	return &SchedulerWrapper{name: name, resource: r, queue: queue, clock: clock, evictedProcs: evictedProcs, logger: logger, selectionFunc: selection, evictor: evictor}
+	return &SchedulerWrapper{name: name, resource: r, queue: queue, clock: clock, evictedProcs: evictedProcs, logger: logger, selectionFunc: selection, evictor: evictor, observer: observer}
 }
 
 func (b *SchedulerWrapper) CheckRunningProcs() {
 	procsToEvict := b.evictor.ChooseToEvict(b.resource.GetProcs())
 	for _, p := range procsToEvict {
 		b.logger.Info(fmt.Sprintf("Evicting process %d from resource %s", p.id, b.name))
+		preempted := !p.IsTaskCompleted()
+		reason := "preempted by " + b.name + " scheduler"
+		if reasoner, ok := b.evictor.(EvictReasoner); ok && preempted {
+			reason = reasoner.EvictReason(p)
+		}
+		resourceName := b.resourceOf(p)
 		b.resource.MustEvict(p)
 		b.evictedProcs = append(b.evictedProcs, p)
+		if preempted {
+			b.notify(EventPreempt, p, resourceName, "", reason)
+		}
+	}
+}
+
+func (b *SchedulerWrapper) resourceOf(p *Process) string {
+	for _, r := range b.resource.Resources() {
+		if r.currentProc == p {
+			return r.name
+		}
 	}
+	return ""
+}
+
+func (b *SchedulerWrapper) notify(eventType EventType, p *Process, resource string, queue string, reason string) {
+	notify(b.observer, Event{Type: eventType, Tick: b.clock.GetCurrentTick(), ProcId: p.id, TaskIndex: p.currentTaskIndex, Resource: resource, Queue: queue, State: p.state, Reason: reason})
 }
 
 func (b *SchedulerWrapper) ProcessQueue() {
@@ -66,8 +97,9 @@ func (b *SchedulerWrapper) ProcessQueue() {
 	}
 }
 
-func (b *SchedulerWrapper) PushToQueue(p *Process) {
+func (b *SchedulerWrapper) PushToQueue(p *Process, reason string) {
 	b.queue.Push(p)
+	b.notify(EventEnqueue, p, "", b.queue.name, reason)
 }
 
 func (b *SchedulerWrapper) GetEvictedProcs() []*Process {
@@ -82,6 +114,10 @@ func (b *SchedulerWrapper) GetResource() Resourcer {
 	return b.resource
 }
 
+func (b *SchedulerWrapper) GetQueue() *ProcQueue {
+	return b.queue
+}
+
 func (b *SchedulerWrapper) assignFromQueue() {
 	freeRes, err := b.resource.GetFree()
 	if err != nil {
@@ -101,4 +137,13 @@ func (b *SchedulerWrapper) assignFromQueue() {
 		return
 	}
 	b.logger.Info(fmt.Sprintf("Assigning process %d to resource %s", nextProc.id, b.name))
+	if freeRes.resourceType == CPU {
+		reason := ""
+		if nextProc.switchOverhead > 0 {
+			reason = fmt.Sprintf("switching context for %d ticks", nextProc.switchOverhead)
+		}
+		b.notify(EventDispatch, nextProc, freeRes.name, "", reason)
+	} else {
+		b.notify(EventIOStart, nextProc, freeRes.name, "", "")
+	}
 }
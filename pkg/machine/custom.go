@@ -0,0 +1,122 @@
+package machine
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+)
+
+// SelectionExpr - picks process with the best score of a user supplied objective
+// like max((w+s)/s) or min(remaining). Ties are resolved in queue order
+type SelectionExpr struct {
+	maximize bool
+	score    *Expression
+}
+
+// NewSelectionExpr - objective must be max(expr) or min(expr)
+func NewSelectionExpr(objective string) (*SelectionExpr, error) {
+	compiled, err := CompileExpression(objective)
+	if err != nil {
+		return nil, err
+	}
+	call, ok := compiled.root.(callNode)
+	if !ok || len(call.args) != 1 || call.name == "abs" {
+		return nil, fmt.Errorf("selection %q must be max(expr) or min(expr)", objective)
+	}
+	return &SelectionExpr{maximize: call.name == "max", score: &Expression{source: objective, root: call.args[0]}}, nil
+}
+
+func (s *SelectionExpr) scoreOf(p *Process) float64 {
+	return s.score.eval(procExprVars(p))
+}
+
+// better - NaN is worse than any number
+func (s *SelectionExpr) better(a, b float64) bool {
+	if math.IsNaN(b) {
+		return !math.IsNaN(a)
+	}
+	if s.maximize {
+		return a > b
+	}
+	return a < b
+}
+
+func (s *SelectionExpr) bestInQueue(queue *ProcQueue) (*Process, float64) {
+	var bestProc *Process
+	best := math.NaN()
+	for _, qe := range queue.GetQueueElements() {
+		score := s.scoreOf(qe.process)
+		if bestProc == nil || s.better(score, best) {
+			bestProc = qe.process
+			best = score
+		}
+	}
+	return bestProc, best
+}
+
+func (s *SelectionExpr) Select(queue *ProcQueue) (*Process, error) {
+	proc, _ := s.bestInQueue(queue)
+	if proc == nil {
+		return &Process{}, errors.New("queue is empty")
+	}
+	return queue.Pick(proc)
+}
+
+// ExprEvictor - evicts completed processes and running processes matching a preemption rule.
+// Rule sees variables of the running process plus its score and the best score in the ready queue,
+// e.g. best < score or run >= 4. At most one process per waiting one is preempted, worst score first
+type ExprEvictor struct {
+	selection *SelectionExpr
+	rule      *Expression
+	queue     *ProcQueue
+}
+
+// NewExprEvictor - empty rule makes the evictor nonpreemptive
+func NewExprEvictor(selection *SelectionExpr, rule string, queue *ProcQueue) (*ExprEvictor, error) {
+	e := &ExprEvictor{selection: selection, queue: queue}
+	if rule == "" {
+		return e, nil
+	}
+	compiled, err := CompileExpression(rule)
+	if err != nil {
+		return nil, err
+	}
+	e.rule = compiled
+	return e, nil
+}
+
+func (e *ExprEvictor) EvictReason(p *Process) string {
+	return fmt.Sprintf("preemption rule %s matched", e.rule)
+}
+
+func (e *ExprEvictor) ChooseToEvict(procs []*Process) []*Process {
+	procsToEvict := make([]*Process, 0)
+	candidates := make([]*Process, 0)
+	scores := make(map[*Process]float64)
+	_, best := e.selection.bestInQueue(e.queue)
+	for _, p := range procs {
+		if p.IsTaskCompleted() {
+			procsToEvict = append(procsToEvict, p)
+			continue
+		}
+		if e.rule == nil {
+			continue
+		}
+		vars := procExprVars(p)
+		vars[exprVarScore] = e.selection.scoreOf(p)
+		vars[exprVarBest] = best
+		if e.rule.eval(vars) != 0 {
+			candidates = append(candidates, p)
+			scores[p] = vars[exprVarScore]
+		}
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return e.selection.better(scores[candidates[j]], scores[candidates[i]])
+	})
+	for i := 0; i < len(candidates) && i < e.queue.Len(); i++ {
+		procsToEvict = append(procsToEvict, candidates[i])
+	}
+	return procsToEvict
+}
@@ -0,0 +1,364 @@
+package machine
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Variables of process available in expressions. Times are of the current burst
+const (
+	exprVarWait      = iota // w - ticks in ready queue since last dispatch
+	exprVarExecuted         // e - ticks executed
+	exprVarService          // s - total ticks of the burst
+	exprVarRemaining        // remaining - s - e
+	exprVarPriority         // priority - user supplied priority
+	exprVarArrival          // arrival - tick the process entered the system
+	exprVarRun              // run - ticks on CPU since last dispatch
+	exprVarScore            // score - value of the selection expression for this process
+	exprVarBest             // best - best score in the ready queue, NaN if queue is empty
+	exprVarCount
+)
+
+var exprVarNames = map[string]int{
+	"w":         exprVarWait,
+	"e":         exprVarExecuted,
+	"s":         exprVarService,
+	"remaining": exprVarRemaining,
+	"priority":  exprVarPriority,
+	"arrival":   exprVarArrival,
+	"run":       exprVarRun,
+	"score":     exprVarScore,
+	"best":      exprVarBest,
+}
+
+type exprVars [exprVarCount]float64
+
+func procExprVars(p *Process) exprVars {
+	var vars exprVars
+	vars[exprVarWait] = float64(p.waitingTime)
+	vars[exprVarExecuted] = float64(p.CurTask().passedTime)
+	vars[exprVarService] = float64(p.CurTask().TotalTime)
+	vars[exprVarRemaining] = float64(p.TaskRemainingTime())
+	vars[exprVarPriority] = float64(p.priority)
+	vars[exprVarArrival] = float64(p.arrivalTime)
+	vars[exprVarRun] = float64(p.runningTime)
+	vars[exprVarScore] = math.NaN()
+	vars[exprVarBest] = math.NaN()
+	return vars
+}
+
+// Expression - compiled arithmetic expression over process variables.
+// Comparisons and logical operators evaluate to 1 or 0
+type Expression struct {
+	source string
+	root   exprNode
+}
+
+func (e *Expression) String() string {
+	return e.source
+}
+
+func (e *Expression) eval(vars exprVars) float64 {
+	return e.root.eval(&vars)
+}
+
+// CompileExpression - parses expression like (w+s)/s or e >= 4 && best < score
+func CompileExpression(source string) (*Expression, error) {
+	p := &exprParser{tokens: tokenizeExpr(source)}
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, fmt.Errorf("expression %q: %w", source, err)
+	}
+	if tok := p.peek(); tok.kind != tokEOF {
+		return nil, fmt.Errorf("expression %q: unexpected %q", source, tok.text)
+	}
+	return &Expression{source: source, root: root}, nil
+}
+
+type exprNode interface {
+	eval(vars *exprVars) float64
+}
+
+type numberNode float64
+
+func (n numberNode) eval(*exprVars) float64 {
+	return float64(n)
+}
+
+type varNode int
+
+func (n varNode) eval(vars *exprVars) float64 {
+	return vars[n]
+}
+
+type unaryNode struct {
+	op  string
+	arg exprNode
+}
+
+func (n unaryNode) eval(vars *exprVars) float64 {
+	v := n.arg.eval(vars)
+	if n.op == "!" {
+		return boolToFloat(v == 0)
+	}
+	return -v
+}
+
+type binaryNode struct {
+	op          string
+	left, right exprNode
+}
+
+func (n binaryNode) eval(vars *exprVars) float64 {
+	l, r := n.left.eval(vars), n.right.eval(vars)
+	switch n.op {
+	case "+":
+		return l + r
+	case "-":
+		return l - r
+	case "*":
+		return l * r
+	case "/":
+		return l / r
+	case "<":
+		return boolToFloat(l < r)
+	case "<=":
+		return boolToFloat(l <= r)
+	case ">":
+		return boolToFloat(l > r)
+	case ">=":
+		return boolToFloat(l >= r)
+	case "==":
+		return boolToFloat(l == r)
+	case "!=":
+		return boolToFloat(l != r)
+	case "&&":
+		return boolToFloat(l != 0 && r != 0)
+	case "||":
+		return boolToFloat(l != 0 || r != 0)
+	}
+	panic(fmt.Sprintf("unknown operator %s", n.op))
+}
+
+type callNode struct {
+	name string
+	args []exprNode
+}
+
+func (n callNode) eval(vars *exprVars) float64 {
+	res := n.args[0].eval(vars)
+	for _, arg := range n.args[1:] {
+		v := arg.eval(vars)
+		switch n.name {
+		case "max":
+			res = math.Max(res, v)
+		case "min":
+			res = math.Min(res, v)
+		}
+	}
+	if n.name == "abs" {
+		return math.Abs(res)
+	}
+	return res
+}
+
+func boolToFloat(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+type exprTokenKind int
+
+const (
+	tokEOF exprTokenKind = iota
+	tokNumber
+	tokIdent
+	tokOp
+	tokInvalid
+)
+
+type exprToken struct {
+	kind exprTokenKind
+	text string
+}
+
+func tokenizeExpr(source string) []exprToken {
+	tokens := make([]exprToken, 0)
+	runes := []rune(source)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case unicode.IsDigit(r) || r == '.':
+			j := i
+			for j < len(runes) && (unicode.IsDigit(runes[j]) || runes[j] == '.') {
+				j++
+			}
+			tokens = append(tokens, exprToken{tokNumber, string(runes[i:j])})
+			i = j
+		case unicode.IsLetter(r) || r == '_':
+			j := i
+			for j < len(runes) && (unicode.IsLetter(runes[j]) || unicode.IsDigit(runes[j]) || runes[j] == '_') {
+				j++
+			}
+			tokens = append(tokens, exprToken{tokIdent, string(runes[i:j])})
+			i = j
+		default:
+			if i+1 < len(runes) {
+				two := string(runes[i : i+2])
+				if slices.Contains([]string{"<=", ">=", "==", "!=", "&&", "||"}, two) {
+					tokens = append(tokens, exprToken{tokOp, two})
+					i += 2
+					continue
+				}
+			}
+			if strings.ContainsRune("+-*/()<>!,", r) {
+				tokens = append(tokens, exprToken{tokOp, string(r)})
+			} else {
+				tokens = append(tokens, exprToken{tokInvalid, string(r)})
+			}
+			i++
+		}
+	}
+	return append(tokens, exprToken{tokEOF, "end of expression"})
+}
+
+// exprParser - recursive descent, from the lowest precedence: || && comparison + - * / unary
+type exprParser struct {
+	tokens []exprToken
+	pos    int
+}
+
+func (p *exprParser) peek() exprToken {
+	return p.tokens[p.pos]
+}
+
+func (p *exprParser) next() exprToken {
+	tok := p.tokens[p.pos]
+	if tok.kind != tokEOF {
+		p.pos++
+	}
+	return tok
+}
+
+func (p *exprParser) acceptOp(ops ...string) (string, bool) {
+	tok := p.peek()
+	if tok.kind == tokOp && slices.Contains(ops, tok.text) {
+		p.pos++
+		return tok.text, true
+	}
+	return "", false
+}
+
+func (p *exprParser) parseBinary(next func() (exprNode, error), ops ...string) (exprNode, error) {
+	left, err := next()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		op, ok := p.acceptOp(ops...)
+		if !ok {
+			return left, nil
+		}
+		right, err := next()
+		if err != nil {
+			return nil, err
+		}
+		left = binaryNode{op, left, right}
+	}
+}
+
+func (p *exprParser) parseOr() (exprNode, error) {
+	return p.parseBinary(p.parseAnd, "||")
+}
+
+func (p *exprParser) parseAnd() (exprNode, error) {
+	return p.parseBinary(p.parseComparison, "&&")
+}
+
+func (p *exprParser) parseComparison() (exprNode, error) {
+	return p.parseBinary(p.parseSum, "<", "<=", ">", ">=", "==", "!=")
+}
+
+func (p *exprParser) parseSum() (exprNode, error) {
+	return p.parseBinary(p.parseProduct, "+", "-")
+}
+
+func (p *exprParser) parseProduct() (exprNode, error) {
+	return p.parseBinary(p.parseUnary, "*", "/")
+}
+
+func (p *exprParser) parseUnary() (exprNode, error) {
+	if op, ok := p.acceptOp("-", "!"); ok {
+		arg, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return unaryNode{op, arg}, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *exprParser) parsePrimary() (exprNode, error) {
+	tok := p.next()
+	switch tok.kind {
+	case tokNumber:
+		v, err := strconv.ParseFloat(tok.text, 64)
+		if err != nil {
+			return nil, fmt.Errorf("bad number %q", tok.text)
+		}
+		return numberNode(v), nil
+	case tokIdent:
+		if _, ok := p.acceptOp("("); ok {
+			return p.parseCall(tok.text)
+		}
+		v, ok := exprVarNames[tok.text]
+		if !ok {
+			return nil, fmt.Errorf("unknown variable %q", tok.text)
+		}
+		return varNode(v), nil
+	case tokOp:
+		if tok.text == "(" {
+			inner, err := p.parseOr()
+			if err != nil {
+				return nil, err
+			}
+			if _, ok := p.acceptOp(")"); !ok {
+				return nil, fmt.Errorf("missing )")
+			}
+			return inner, nil
+		}
+	}
+	return nil, fmt.Errorf("unexpected %q", tok.text)
+}
+
+func (p *exprParser) parseCall(name string) (exprNode, error) {
+	if !slices.Contains([]string{"min", "max", "abs"}, name) {
+		return nil, fmt.Errorf("unknown function %q", name)
+	}
+	args := make([]exprNode, 0)
+	for {
+		arg, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, arg)
+		if _, ok := p.acceptOp(","); !ok {
+			break
+		}
+	}
+	if _, ok := p.acceptOp(")"); !ok {
+		return nil, fmt.Errorf("missing ) after arguments of %s", name)
+	}
+	if name == "abs" && len(args) != 1 {
+		return nil, fmt.Errorf("abs takes one argument")
+	}
+	return callNode{name, args}, nil
+}
@@ -0,0 +1,187 @@
+package machine
+
+import (
+	"io"
+	"log/slog"
+	"math"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestCompileExpression(t *testing.T) {
+	vars := exprVars{}
+	vars[exprVarWait] = 4
+	vars[exprVarExecuted] = 1
+	vars[exprVarService] = 2
+	vars[exprVarRemaining] = 1
+
+	tests := []struct {
+		source string
+		want   float64
+	}{
+		{"1 - - 2", 3},
+		{"1 - 2 - 3", -4},
+		{"8 / 4 / 2", 1},
+		{"1 + 2 * 3", 7},
+		{"(1 + 2) * 3", 9},
+		{"-2 * 3", -6},
+		// comparisons are left associative and yield 1 or 0
+		{"3 < 2 < 1", 1},
+		{"1 < 2 < 0.5", 0},
+		{"2 == 2 == 1", 1},
+		// && binds tighter than ||
+		{"1 || 0 && 0", 1},
+		{"(1 || 0) && 0", 0},
+		{"0 && 1 || 1", 1},
+		// ! binds tighter than comparison and arithmetic
+		{"!0 + 1", 2},
+		{"!1 == 0", 1},
+		{"!!3", 1},
+		{"!(1 < 2)", 0},
+		{"(w+s)/s", 3},
+		{"w >= 4 && e < s", 1},
+		{"max(w, s, 7)", 7},
+		{"min(w, s) - remaining", 1},
+		{"abs(e - w)", 3},
+	}
+	for _, tt := range tests {
+		expr, err := CompileExpression(tt.source)
+		if err != nil {
+			t.Errorf("%q: %v", tt.source, err)
+			continue
+		}
+		if got := expr.eval(vars); got != tt.want {
+			t.Errorf("%q = %v, want %v", tt.source, got, tt.want)
+		}
+	}
+}
+
+func TestCompileExpressionErrors(t *testing.T) {
+	tests := []struct {
+		source string
+		err    string
+	}{
+		{"foo + 1", `unknown variable "foo"`},
+		{"sqrt(w)", `unknown function "sqrt"`},
+		{"(w + 1", "missing )"},
+		{"max(w, s", "missing ) after arguments of max"},
+		{"abs(w, s)", "abs takes one argument"},
+		{"w 1", `unexpected "1"`},
+		{"w + 1)", `unexpected ")"`},
+		{"w $ 1", `unexpected "$"`},
+		{"1..2", `bad number "1..2"`},
+		{"", `unexpected "end of expression"`},
+	}
+	for _, tt := range tests {
+		_, err := CompileExpression(tt.source)
+		if err == nil || !strings.Contains(err.Error(), tt.err) {
+			t.Errorf("%q: error %v, want %q", tt.source, err, tt.err)
+		}
+	}
+}
+
+func TestNewSelectionExprErrors(t *testing.T) {
+	for _, objective := range []string{"w + s", "remaining", "abs(w)", "max(w, s)", "max(w) + 1", "min(unknown)"} {
+		if _, err := NewSelectionExpr(objective); err == nil {
+			t.Errorf("%q: expected error", objective)
+		}
+	}
+}
+
+// exprTestProc - process with the current burst of service ticks, executed ticks done
+func exprTestProc(id int, service int, executed int, waiting int) *Process {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	tasks := []Task{{ResouceType: CPU, TotalTime: service, passedTime: executed}}
+	p := NewProcess(id, 0, 0, tasks, logger, &Clock{})
+	p.waitingTime = waiting
+	return p
+}
+
+func TestExprNaNAndInf(t *testing.T) {
+	expr, err := CompileExpression("w/e")
+	if err != nil {
+		t.Fatal(err)
+	}
+	vars := exprVars{}
+	if got := expr.eval(vars); !math.IsNaN(got) {
+		t.Errorf("0/0 = %v, want NaN", got)
+	}
+	vars[exprVarWait] = 3
+	if got := expr.eval(vars); !math.IsInf(got, 1) {
+		t.Errorf("3/0 = %v, want +Inf", got)
+	}
+
+	tests := []struct {
+		objective string
+		// procs - service, executed and waiting ticks of queued processes
+		procs [][3]int
+		want  int
+	}{
+		// NaN of 0/0 is worse than any number, +Inf of 3/0 is the largest one
+		{"max(w/e)", [][3]int{{5, 0, 0}, {5, 1, 2}, {5, 0, 3}}, 2},
+		{"max(w/e)", [][3]int{{5, 0, 0}, {5, 1, 2}}, 1},
+		{"min(w/e)", [][3]int{{5, 0, 0}, {5, 0, 3}, {5, 1, 2}}, 2},
+		{"min(w/e)", [][3]int{{5, 0, 0}, {5, 0, 0}}, 0},
+	}
+	for _, tt := range tests {
+		selection, err := NewSelectionExpr(tt.objective)
+		if err != nil {
+			t.Fatal(err)
+		}
+		queue := NewProcQueue("CPUs", &Clock{})
+		for id, p := range tt.procs {
+			queue.Push(exprTestProc(id, p[0], p[1], p[2]))
+		}
+		got, err := selection.Select(queue)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.id != tt.want {
+			t.Errorf("%s of %v selected %d, want %d", tt.objective, tt.procs, got.id, tt.want)
+		}
+	}
+}
+
+func TestExprEvictor(t *testing.T) {
+	selection, err := NewSelectionExpr("min(remaining)")
+	if err != nil {
+		t.Fatal(err)
+	}
+	queue := NewProcQueue("CPUs", &Clock{})
+	queue.Push(exprTestProc(0, 2, 0, 0))
+
+	// remaining 5, 3 and 1 ticks and one which finished its burst
+	running := []*Process{exprTestProc(1, 6, 1, 0), exprTestProc(2, 4, 1, 0), exprTestProc(3, 2, 1, 0), exprTestProc(4, 3, 3, 0)}
+	for _, p := range running[:3] {
+		p.state = RUNNING
+	}
+	running[3].state = BLOCKED
+
+	tests := []struct {
+		rule string
+		want []int
+	}{
+		// one process waits, so only the worst of the two with larger remaining time is preempted
+		{"best < score", []int{4, 1}},
+		{"", []int{4}},
+		{"run >= 0", []int{4, 1}},
+		{"best > score", []int{4, 3}},
+	}
+	for _, tt := range tests {
+		evictor, err := NewExprEvictor(selection, tt.rule, queue)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got := make([]int, 0)
+		for _, p := range evictor.ChooseToEvict(running) {
+			got = append(got, p.id)
+		}
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("%q evicted %v, want %v", tt.rule, got, tt.want)
+		}
+	}
+	if _, err := NewExprEvictor(selection, "best <", queue); err == nil {
+		t.Error("expected error of bad rule")
+	}
+}
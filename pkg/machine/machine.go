@@ -21,41 +21,51 @@ Task:
 package machine
 
 import (
+	"cmp"
+	"context"
 	"fmt"
 	"log/slog"
 	"slices"
 	"strconv"
 )
 
-type SnapshotStateFunc func(state DumpState)
-
 type Machine struct {
 	cpuScheduler Scheduler
-	io1Scheduler Scheduler
-	io2Scheduler Scheduler
-
-	unscheduledProcs  []*Process
-	runningProcs      []*Process
-	clock             *Clock
-	logger            *slog.Logger
-	snapshotStateFunc SnapshotStateFunc
-	cpuCount          int
+	// ioSchedulers[n-1] serves IO device n
+	ioSchedulers []Scheduler
+
+	unscheduledProcs []*Process
+	runningProcs     []*Process
+	clock            *Clock
+	logger           *slog.Logger
+	observer         Observer
+	cpuCount         int
+	tieBreak         TieBreak
+	// entrants - processes pushed to CPU queue on the current tick
+	entrants map[*Process]entrantKind
 }
 type DumpState struct {
 	Tick      string
 	CpusState []string
-	Io1State  string
-	Io2State  string
+	IoStates  []string
+	// Queues - waiting processes of CPU queue and then of every IO queue
+	Queues []QueueSnapshot
+}
+
+// QueueSnapshot - ids of processes in queue order, in the same format as resource states
+type QueueSnapshot struct {
+	Name  string
+	Procs []string
 }
 type Clock struct {
 	CurrentTick int
 }
 
-func NewDumpState(tick string, cpusStateString []string, io1State string, io2State string) DumpState {
-	return DumpState{tick, cpusStateString, io1State, io2State}
+func NewDumpState(tick string, cpusStateString []string, ioStates []string, queues []QueueSnapshot) DumpState {
+	return DumpState{tick, cpusStateString, ioStates, queues}
 }
-func NewMachine(cpuScheduler Scheduler, io1Scheduler Scheduler, io2Scheduler Scheduler, clock *Clock, logger *slog.Logger, snapshotStateFunc SnapshotStateFunc, cpuCount int) Machine {
-	return Machine{cpuScheduler, io1Scheduler, io2Scheduler, []*Process{}, []*Process{}, clock, logger, snapshotStateFunc, cpuCount}
+func NewMachine(cpuScheduler Scheduler, ioSchedulers []Scheduler, clock *Clock, logger *slog.Logger, observer Observer, cpuCount int, tieBreak TieBreak) Machine {
+	return Machine{cpuScheduler, ioSchedulers, []*Process{}, []*Process{}, clock, logger, observer, cpuCount, tieBreak, map[*Process]entrantKind{}}
 }
 
 func (c *Clock) GetCurrentTick() int {
@@ -82,38 +92,77 @@ func (m *Machine) allDone() bool {
 	return len(m.runningProcs) == 0 && len(m.unscheduledProcs) == 0
 }
 
-func (m *Machine) loop() {
+func (m *Machine) loop(ctx context.Context) error {
 	for {
 		if m.allDone() {
-			break
+			return nil
+		}
+		if err := ctx.Err(); err != nil {
+			return err
 		}
 		m.tick()
 	}
 }
 
+func (m *Machine) schedulers() []Scheduler {
+	return append([]Scheduler{m.cpuScheduler}, m.ioSchedulers...)
+}
+
 func (m *Machine) tick() {
-	// problem: evicted process comes before new process?
+	clear(m.entrants)
+	// new procs are queued before eviction, so preemptive evictors can compare them with running ones
 	m.checkForNewProcs()
 
-	m.cpuScheduler.CheckRunningProcs()
-	m.io1Scheduler.CheckRunningProcs()
-	m.io2Scheduler.CheckRunningProcs()
+	for _, s := range m.schedulers() {
+		s.CheckRunningProcs()
+	}
 
 	m.handleAllEvictedProcs()
+	m.orderEntrants()
 
-	m.cpuScheduler.ProcessQueue()
-	m.io1Scheduler.ProcessQueue()
-	m.io2Scheduler.ProcessQueue()
-
-	if m.clock.GetCurrentTick() == 0 {
-		m.snapshotStateFunc(m.prepareDumpHeader())
+	for _, s := range m.schedulers() {
+		s.ProcessQueue()
 	}
-	m.snapshotStateFunc(m.dumpState())
 
+	m.observer.OnTick(m.dumpState())
+
+	procResources := m.procResources()
 	m.clock.CurrentTick++
 
 	for _, p := range m.runningProcs {
+		taskIndex := p.currentTaskIndex
 		p.Tick()
+		if p.currentTaskIndex != taskIndex {
+			m.notifyTaskCompleted(p, taskIndex, procResources[p])
+		}
+	}
+}
+
+// procResources - resource each running process occupies
+func (m *Machine) procResources() map[*Process]*Resource {
+	procResources := make(map[*Process]*Resource)
+	for _, s := range m.schedulers() {
+		for _, r := range s.GetResource().Resources() {
+			if r.state == BUSY {
+				procResources[r.currentProc] = r
+			}
+		}
+	}
+	return procResources
+}
+
+func (m *Machine) notifyTaskCompleted(p *Process, taskIndex int, r *Resource) {
+	e := Event{Type: EventTaskComplete, Tick: m.GetCurrentTick(), ProcId: p.id, TaskIndex: taskIndex, State: p.state, Reason: "cpu burst finished"}
+	if r != nil {
+		e.Resource = r.name
+		if r.resourceType.IsIO() {
+			e.Type = EventIOEnd
+			e.Reason = "io burst finished"
+		}
+	}
+	notify(m.observer, e)
+	if p.state == TERMINATED {
+		notify(m.observer, Event{Type: EventTerminate, Tick: m.GetCurrentTick(), ProcId: p.id, TaskIndex: taskIndex, Resource: e.Resource, State: p.state, Reason: "last burst finished"})
 	}
 }
 
@@ -125,7 +174,8 @@ func (m *Machine) checkForNewProcs() {
 			continue
 		}
 		m.logger.Info(fmt.Sprintf("Process %d arrived at tick %d", p.id, m.GetCurrentTick()))
-		m.cpuScheduler.PushToQueue(p)
+		notify(m.observer, Event{Type: EventArrival, Tick: m.GetCurrentTick(), ProcId: p.id, TaskIndex: p.currentTaskIndex, State: p.state, Reason: fmt.Sprintf("arrival time %d", p.arrivalTime)})
+		m.pushToCpuQueue(p, entrantNew)
 		m.runningProcs = append(m.runningProcs, p)
 		unscheduleCandidates = append(unscheduleCandidates, p)
 	}
@@ -136,18 +186,45 @@ func (m *Machine) checkForNewProcs() {
 }
 
 func (m *Machine) handleAllEvictedProcs() {
-	ep := m.cpuScheduler.GetEvictedProcs()
-	ep = append(ep, m.io1Scheduler.GetEvictedProcs()...)
-	ep = append(ep, m.io2Scheduler.GetEvictedProcs()...)
-	for _, p := range ep {
-		m.handleEvictedProc(p)
+	for _, s := range m.schedulers() {
+		kind := entrantReturning
+		if s == m.cpuScheduler {
+			kind = entrantPreempted
+		}
+		for _, p := range s.GetEvictedProcs() {
+			m.handleEvictedProc(p, kind)
+		}
+	}
+	for _, s := range m.schedulers() {
+		s.ClearEvictedProcs()
 	}
-	m.cpuScheduler.ClearEvictedProcs()
-	m.io1Scheduler.ClearEvictedProcs()
-	m.io2Scheduler.ClearEvictedProcs()
 }
 
-func (m *Machine) handleEvictedProc(p *Process) {
+func (m *Machine) pushToCpuQueue(p *Process, kind entrantKind) {
+	m.entrants[p] = kind
+	m.cpuScheduler.PushToQueue(p, kind.reason())
+}
+
+// orderEntrants - applies tie break to processes which entered CPU queue on the current tick
+func (m *Machine) orderEntrants() {
+	m.cpuScheduler.GetQueue().sortEnteredAt(m.GetCurrentTick(), func(a, b *Process) int {
+		switch m.tieBreak {
+		case TieBreakReturningFirst:
+			return cmp.Compare(m.entrants[b], m.entrants[a])
+		case TieBreakById:
+			return cmp.Compare(a.id, b.id)
+		case TieBreakByArrival:
+			if a.arrivalTime != b.arrivalTime {
+				return cmp.Compare(a.arrivalTime, b.arrivalTime)
+			}
+			return cmp.Compare(a.id, b.id)
+		default:
+			return cmp.Compare(m.entrants[a], m.entrants[b])
+		}
+	})
+}
+
+func (m *Machine) handleEvictedProc(p *Process, kind entrantKind) {
 	switch p.state {
 	case TERMINATED:
 		m.logger.Info(fmt.Sprintf("Process %d is done at tick %d", p.id, m.GetCurrentTick()))
@@ -160,7 +237,7 @@ func (m *Machine) handleEvictedProc(p *Process) {
 		}
 	case RUNNING, READY:
 		// not finished or came from IO
-		m.cpuScheduler.PushToQueue(p)
+		m.pushToCpuQueue(p, kind)
 	case BLOCKED:
 		m.pushToIO(p)
 	case READS_IO:
@@ -169,29 +246,37 @@ func (m *Machine) handleEvictedProc(p *Process) {
 }
 
 func (m *Machine) pushToIO(p *Process) {
-	switch p.CurTask().ResouceType {
-	case IO1:
-		m.logger.Debug(fmt.Sprintf("Process %d is blocked on IO1", p.id))
-		m.io1Scheduler.PushToQueue(p)
-	case IO2:
-		m.logger.Debug(fmt.Sprintf("Process %d is blocked on IO2", p.id))
-		m.io2Scheduler.PushToQueue(p)
-	case CPU:
+	resourceType := p.CurTask().ResouceType
+	if !resourceType.IsIO() {
 		panic(fmt.Sprintf("Proc %d is blocked by current task is cpu", p.id))
 	}
+	if resourceType.Device() > len(m.ioSchedulers) {
+		panic(fmt.Sprintf("Proc %d is blocked on %s but machine has %d IO devices", p.id, resourceType, len(m.ioSchedulers)))
+	}
+	m.logger.Debug(fmt.Sprintf("Process %d is blocked on %s", p.id, resourceType))
+	m.ioSchedulers[resourceType.Device()-1].PushToQueue(p, "cpu burst finished")
 }
 
-func (m *Machine) prepareDumpHeader() DumpState {
+// DumpHeader - names of the columns of DumpState
+func (m *Machine) DumpHeader() DumpState {
 	cpusHeader := make([]string, m.cpuCount)
 	for i := 0; i < m.cpuCount; i++ {
 		cpusHeader[i] = fmt.Sprintf("CPU%d", i+1)
 	}
-	return NewDumpState("Tick", cpusHeader, "IO1", "IO2")
+	ioHeader := make([]string, len(m.ioSchedulers))
+	for i := range m.ioSchedulers {
+		ioHeader[i] = IO(i + 1).String()
+	}
+	queuesHeader := make([]QueueSnapshot, 0, len(m.ioSchedulers)+1)
+	for _, s := range m.schedulers() {
+		queuesHeader = append(queuesHeader, QueueSnapshot{Name: s.GetQueue().name})
+	}
+	return NewDumpState("Tick", cpusHeader, ioHeader, queuesHeader)
 }
 
 // DumpState - prints running processes on each cpu and io in one line
 // output format:
-// {tick} {procid on first cpu} {procid on second cpu} ... {procid on last cpu} {procid on io1} {procid on io2}
+// {tick} {procid on first cpu} {procid on second cpu} ... {procid on last cpu} {procid on io1} ... {procid on last io}
 // if no proc on cpu or io, output - instead of id
 func (m *Machine) dumpState() DumpState {
 	cpusStateString := make([]string, m.cpuCount)
@@ -201,13 +286,17 @@ func (m *Machine) dumpState() DumpState {
 		cpusStateString[i] = resourceStateToString(cpu)
 	}
 
-	io1 := m.io1Scheduler.GetResource().(*Resource)
-	io1State := resourceStateToString(io1)
+	ioStates := make([]string, len(m.ioSchedulers))
+	for i, s := range m.ioSchedulers {
+		ioStates[i] = resourceStateToString(s.GetResource().(*Resource))
+	}
 
-	io2 := m.io2Scheduler.GetResource().(*Resource)
-	io2State := resourceStateToString(io2)
+	queues := make([]QueueSnapshot, 0, len(m.ioSchedulers)+1)
+	for _, s := range m.schedulers() {
+		queues = append(queues, s.GetQueue().snapshot())
+	}
 
-	return NewDumpState(strconv.Itoa(m.GetCurrentTick()), cpusStateString, io1State, io2State)
+	return NewDumpState(strconv.Itoa(m.GetCurrentTick()), cpusStateString, ioStates, queues)
 }
 
 func resourceStateToString(r *Resource) string {
@@ -218,8 +307,14 @@ func resourceStateToString(r *Resource) string {
 }
 
 func (m *Machine) Run(processes []*Process) {
+	// background context is never cancelled
+	_ = m.RunContext(context.Background(), processes)
+}
+
+// RunContext - same as Run, but stops between ticks with ctx error once ctx is done
+func (m *Machine) RunContext(ctx context.Context, processes []*Process) error {
 	m.unscheduledProcs = make([]*Process, len(processes))
 	copy(m.unscheduledProcs, processes)
 
-	m.loop()
+	return m.loop(ctx)
 }
@@ -0,0 +1,186 @@
+package machine
+
+type EventType int
+
+const (
+	EventArrival      EventType = iota // process entered the system
+	EventEnqueue                       // process entered a CPU or IO queue
+	EventDispatch                      // process assigned to CPU
+	EventPreempt                       // running process evicted from CPU before its burst completed
+	EventIOStart                       // process assigned to IO device
+	EventIOEnd                         // IO burst completed
+	EventTaskComplete                  // CPU burst completed
+	EventTerminate                     // last burst completed
+)
+
+func (t EventType) String() string {
+	switch t {
+	case EventArrival:
+		return "arrival"
+	case EventEnqueue:
+		return "enqueue"
+	case EventDispatch:
+		return "dispatch"
+	case EventPreempt:
+		return "preempt"
+	case EventIOStart:
+		return "io_start"
+	case EventIOEnd:
+		return "io_end"
+	case EventTaskComplete:
+		return "task_complete"
+	case EventTerminate:
+		return "terminate"
+	}
+	return "unknown"
+}
+
+// Event - single state transition of a process.
+// Burst completions are reported at the first tick after the last executed one
+type Event struct {
+	Type EventType
+	Tick int
+	// ProcId - zero based id of the process
+	ProcId int
+	// TaskIndex - index of the burst the event relates to
+	TaskIndex int
+	// Resource - name of CPU or IO device, empty if event is not bound to a resource
+	Resource string
+	// Queue - name of the queue for enqueue events
+	Queue string
+	// State - state of the process after the event
+	State ProcState
+	// Reason - why the transition happened, empty if there is nothing to add to the event type
+	Reason string
+}
+
+// Observer - receives events of a machine. Embed NoopObserver to implement only needed callbacks
+type Observer interface {
+	OnArrival(e Event)
+	OnEnqueue(e Event)
+	OnDispatch(e Event)
+	OnPreempt(e Event)
+	OnIOStart(e Event)
+	OnIOEnd(e Event)
+	OnTaskComplete(e Event)
+	OnTerminate(e Event)
+	// OnTick - occupancy of resources after scheduling of the tick
+	OnTick(state DumpState)
+}
+
+type NoopObserver struct{}
+
+func (NoopObserver) OnArrival(Event)      {}
+func (NoopObserver) OnEnqueue(Event)      {}
+func (NoopObserver) OnDispatch(Event)     {}
+func (NoopObserver) OnPreempt(Event)      {}
+func (NoopObserver) OnIOStart(Event)      {}
+func (NoopObserver) OnIOEnd(Event)        {}
+func (NoopObserver) OnTaskComplete(Event) {}
+func (NoopObserver) OnTerminate(Event)    {}
+func (NoopObserver) OnTick(DumpState)     {}
+
+// EventFunc - observer which passes every event to a single function and ignores ticks
+type EventFunc func(e Event)
+
+func (f EventFunc) OnArrival(e Event)      { f(e) }
+func (f EventFunc) OnEnqueue(e Event)      { f(e) }
+func (f EventFunc) OnDispatch(e Event)     { f(e) }
+func (f EventFunc) OnPreempt(e Event)      { f(e) }
+func (f EventFunc) OnIOStart(e Event)      { f(e) }
+func (f EventFunc) OnIOEnd(e Event)        { f(e) }
+func (f EventFunc) OnTaskComplete(e Event) { f(e) }
+func (f EventFunc) OnTerminate(e Event)    { f(e) }
+func (f EventFunc) OnTick(DumpState)       {}
+
+// TickFunc - observer which only receives per tick states
+type TickFunc func(state DumpState)
+
+func (TickFunc) OnArrival(Event)          {}
+func (TickFunc) OnEnqueue(Event)          {}
+func (TickFunc) OnDispatch(Event)         {}
+func (TickFunc) OnPreempt(Event)          {}
+func (TickFunc) OnIOStart(Event)          {}
+func (TickFunc) OnIOEnd(Event)            {}
+func (TickFunc) OnTaskComplete(Event)     {}
+func (TickFunc) OnTerminate(Event)        {}
+func (f TickFunc) OnTick(state DumpState) { f(state) }
+
+// MultiObserver - fans out to all observers in order
+type MultiObserver []Observer
+
+func (o MultiObserver) OnArrival(e Event) {
+	for _, obs := range o {
+		obs.OnArrival(e)
+	}
+}
+
+func (o MultiObserver) OnEnqueue(e Event) {
+	for _, obs := range o {
+		obs.OnEnqueue(e)
+	}
+}
+
+func (o MultiObserver) OnDispatch(e Event) {
+	for _, obs := range o {
+		obs.OnDispatch(e)
+	}
+}
+
+func (o MultiObserver) OnPreempt(e Event) {
+	for _, obs := range o {
+		obs.OnPreempt(e)
+	}
+}
+
+func (o MultiObserver) OnIOStart(e Event) {
+	for _, obs := range o {
+		obs.OnIOStart(e)
+	}
+}
+
+func (o MultiObserver) OnIOEnd(e Event) {
+	for _, obs := range o {
+		obs.OnIOEnd(e)
+	}
+}
+
+func (o MultiObserver) OnTaskComplete(e Event) {
+	for _, obs := range o {
+		obs.OnTaskComplete(e)
+	}
+}
+
+func (o MultiObserver) OnTerminate(e Event) {
+	for _, obs := range o {
+		obs.OnTerminate(e)
+	}
+}
+
+func (o MultiObserver) OnTick(state DumpState) {
+	for _, obs := range o {
+		obs.OnTick(state)
+	}
+}
+
+// notify - calls the callback of observer matching e.Type
+func notify(o Observer, e Event) {
+	switch e.Type {
+	case EventArrival:
+		o.OnArrival(e)
+	case EventEnqueue:
+		o.OnEnqueue(e)
+	case EventDispatch:
+		o.OnDispatch(e)
+	case EventPreempt:
+		o.OnPreempt(e)
+	case EventIOStart:
+		o.OnIOStart(e)
+	case EventIOEnd:
+		o.OnIOEnd(e)
+	case EventTaskComplete:
+		o.OnTaskComplete(e)
+	case EventTerminate:
+		o.OnTerminate(e)
+	}
+}
@@ -16,6 +16,22 @@ const (
 	TERMINATED                  // completed
 )
 
+func (s ProcState) String() string {
+	switch s {
+	case READY:
+		return "ready"
+	case RUNNING:
+		return "running"
+	case BLOCKED:
+		return "blocked"
+	case READS_IO:
+		return "io"
+	case TERMINATED:
+		return "terminated"
+	}
+	return "unknown"
+}
+
 type Task struct {
 	ResouceType ResourceType
 	passedTime  int
@@ -34,11 +50,43 @@ type ProcStats struct {
 	StartTime          int
 	ReadyOrBlockedTime int
 	TurnaroundTime     int
+	// ResponseTime - first dispatch minus arrival
+	ResponseTime int
+	// ReadyWaitTime - ticks in CPU ready queue
+	ReadyWaitTime int
+	// IOWaitTime - ticks in IO queues
+	IOWaitTime     int
+	CPUServiceTime int
+	IOServiceTime  int
+	// LongestReadyWait - longest continuous stay in CPU ready queue
+	LongestReadyWait int
+	// SwitchOverheadTime - ticks on CPU spent on context switches, part of turnaround but not of service
+	SwitchOverheadTime int
+	// SwitchCounters - CPU dispatches, preemptions, yields to IO and migrations
+	SwitchCounters
+}
+
+// TaskStats - timing of a single burst. Times are ticks, Start and Finish are the first and the last executed tick
+type TaskStats struct {
+	ProcId       int
+	TaskIndex    int
+	ResourceType ResourceType
+	Duration     int
+	// ReadyTime - first tick the burst was current, i.e. arrival or the tick after previous burst finished
+	ReadyTime int
+	StartTime int
+	// WaitTime - ticks in CPU or IO queue
+	WaitTime    int
+	Preemptions int
+	// SwitchOverhead - ticks on CPU spent on context switches
+	SwitchOverhead int
+	FinishTime     int
 }
 
 type Process struct {
 	id          int
 	arrivalTime int
+	priority    int
 	state       ProcState
 
 	currentTaskIndex int
@@ -51,19 +99,57 @@ type Process struct {
 	logger *slog.Logger
 
 	procStats *ProcStats
+	taskStats []TaskStats
+	// lastCpu - CPU of the previous dispatch to detect migrations
+	lastCpu *Resource
+	// switchOverhead - context switch ticks left before the process makes progress on CPU
+	switchOverhead int
 
 	clock logging.GlobalTimer
 }
 
-func NewProcess(id int, arrivalTime int, tasks []Task, logger *slog.Logger, clock logging.GlobalTimer) *Process {
+func NewProcess(id int, arrivalTime int, priority int, tasks []Task, logger *slog.Logger, clock logging.GlobalTimer) *Process {
 	procStats := &ProcStats{ProcId: id, EntranceTime: arrivalTime, StartTime: -1, ReadyOrBlockedTime: 0}
-	return &Process{id, arrivalTime, READY, 0, tasks, 0, 0, 0, logger, procStats, clock}
+	taskStats := make([]TaskStats, len(tasks))
+	for i, t := range tasks {
+		taskStats[i] = TaskStats{ProcId: id, TaskIndex: i, ResourceType: t.ResouceType, Duration: t.TotalTime, ReadyTime: -1, StartTime: -1, FinishTime: -1}
+	}
+	return &Process{id, arrivalTime, priority, READY, 0, tasks, 0, 0, 0, logger, procStats, taskStats, nil, 0, clock}
+}
+
+func (p *Process) Id() int {
+	return p.id
+}
+
+func (p *Process) ArrivalTime() int {
+	return p.arrivalTime
+}
+
+func (p *Process) Priority() int {
+	return p.priority
+}
+
+// WaitingTime - ticks spent in the current queue
+func (p *Process) WaitingTime() int {
+	return p.waitingTime
+}
+
+// RunningTime - ticks since the last dispatch to CPU
+func (p *Process) RunningTime() int {
+	return p.runningTime
 }
 
 func (p *Process) GetStats() ProcStats {
 	return *p.procStats
 }
 
+// GetTaskStats - stats of every burst in order
+func (p *Process) GetTaskStats() []TaskStats {
+	taskStats := make([]TaskStats, len(p.taskStats))
+	copy(taskStats, p.taskStats)
+	return taskStats
+}
+
 func (p *Process) EstimatedTaskTime() int {
 	return p.tasks[p.currentTaskIndex].TotalTime
 }
@@ -110,19 +196,66 @@ func (p *Process) Tick() {
 }
 
 func (p *Process) updateStatsOnTickBefore() {
+	switching := p.isSwitching()
 	if p.state == RUNNING || p.state == READS_IO {
 		if p.procStats.StartTime == -1 {
-			p.procStats.StartTime = p.clock.GetCurrentTick()
+			// processes tick after clock moved to the next tick
+			p.procStats.StartTime = p.clock.GetCurrentTick() - 1
+			p.procStats.ResponseTime = p.procStats.StartTime - p.procStats.EntranceTime
+		}
+		if !switching {
+			p.procStats.ServiceTime++
 		}
-		p.procStats.ServiceTime++
 	} else if p.state == READY || p.state == BLOCKED {
 		p.procStats.ReadyOrBlockedTime++
 	}
+
+	switch {
+	case switching:
+		p.procStats.SwitchOverheadTime++
+		p.lastCpu.switchTime++
+	case p.state == RUNNING:
+		p.procStats.CPUServiceTime++
+	case p.state == READS_IO:
+		p.procStats.IOServiceTime++
+	case p.state == READY:
+		p.procStats.ReadyWaitTime++
+	case p.state == BLOCKED:
+		p.procStats.IOWaitTime++
+	}
+
+	if p.currentTaskIndex < len(p.taskStats) {
+		p.updateTaskStats(&p.taskStats[p.currentTaskIndex])
+	}
+}
+
+func (p *Process) updateTaskStats(taskStats *TaskStats) {
+	// processes tick after clock moved to the next tick
+	tick := p.clock.GetCurrentTick() - 1
+	if taskStats.ReadyTime == -1 {
+		taskStats.ReadyTime = tick
+	}
+	switch p.state {
+	case RUNNING, READS_IO:
+		if taskStats.StartTime == -1 {
+			taskStats.StartTime = tick
+		}
+		if p.isSwitching() {
+			taskStats.SwitchOverhead++
+		}
+	case READY, BLOCKED:
+		taskStats.WaitTime++
+	}
+}
+
+// isSwitching - process occupies CPU, but context switch is not finished yet
+func (p *Process) isSwitching() bool {
+	return p.state == RUNNING && p.switchOverhead > 0
 }
 
 func (p *Process) updateGlobalProcStatsAfter() {
 	if p.state == TERMINATED {
-		p.procStats.TurnaroundTime = p.procStats.ServiceTime + p.procStats.ReadyOrBlockedTime
+		p.procStats.TurnaroundTime = p.procStats.ServiceTime + p.procStats.ReadyOrBlockedTime + p.procStats.SwitchOverheadTime
 		p.procStats.ExitTime = p.procStats.TurnaroundTime + p.procStats.EntranceTime - 1
 	}
 }
@@ -133,10 +266,16 @@ func (p *Process) incrementCounters() {
 	case TERMINATED:
 		p.logger.Warn(fmt.Sprintf("Process %d is already terminated", p.id))
 	case READY:
+		// waiting time is reset on dispatch, so it is the length of the current stay in ready queue
 		p.waitingTime++
+		p.procStats.LongestReadyWait = max(p.procStats.LongestReadyWait, p.waitingTime)
 	case BLOCKED:
 		p.blockedTime++
 	case RUNNING, READS_IO:
+		if p.isSwitching() {
+			p.switchOverhead--
+			break
+		}
 		p.runningTime++
 		p.CurTask().passedTime++
 	}
@@ -159,6 +298,7 @@ func (p *Process) updateState() {
 }
 
 func (p *Process) completeTask() {
+	p.taskStats[p.currentTaskIndex].FinishTime = p.clock.GetCurrentTick() - 1
 	p.runningTime = 0
 	p.currentTaskIndex++
 	if p.currentTaskIndex >= len(p.tasks) {
@@ -169,9 +309,9 @@ func (p *Process) completeTask() {
 	case CPU:
 		p.state = READY
 		p.logger.Debug(fmt.Sprintf("Process %d ready", p.id))
-	case IO1, IO2:
+	default:
 		p.state = BLOCKED
-		p.logger.Debug(fmt.Sprintf("Process %d blocked on IO%d", p.id, p.CurTask().ResouceType-IO1+1))
+		p.logger.Debug(fmt.Sprintf("Process %d blocked on %s", p.id, p.CurTask().ResouceType))
 	}
 }
 
@@ -182,6 +322,7 @@ func (p *Process) onEvict() {
 	switch p.state {
 	case RUNNING:
 		p.state = READY
+		p.taskStats[p.currentTaskIndex].Preemptions++
 	case READS_IO:
 		p.state = BLOCKED
 		panic(fmt.Sprintf("Process %d evicted in READS_IO state but IO scheduler is nonpreemptive", p.id))
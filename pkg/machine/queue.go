@@ -3,6 +3,7 @@ package machine
 import (
 	"errors"
 	"fmt"
+	"slices"
 
 	logger "github.com/Moleus/os-solver/pkg/logging"
 )
@@ -12,6 +13,15 @@ type QueueElement struct {
 	enterTime int
 }
 
+func (e QueueElement) Process() *Process {
+	return e.process
+}
+
+// EnterTime - tick the process was pushed to the queue
+func (e QueueElement) EnterTime() int {
+	return e.enterTime
+}
+
 type ProcQueue struct {
 	name     string
 	elements []QueueElement
@@ -53,3 +63,22 @@ func (pq *ProcQueue) Len() int {
 func (pq *ProcQueue) GetQueueElements() []QueueElement {
 	return pq.elements
 }
+
+// sortEnteredAt - stable sorts elements pushed at tick. They are always at the tail of the queue
+func (pq *ProcQueue) sortEnteredAt(tick int, cmp func(a, b *Process) int) {
+	start := len(pq.elements)
+	for start > 0 && pq.elements[start-1].enterTime == tick {
+		start--
+	}
+	slices.SortStableFunc(pq.elements[start:], func(a, b QueueElement) int {
+		return cmp(a.process, b.process)
+	})
+}
+
+func (pq *ProcQueue) snapshot() QueueSnapshot {
+	procs := make([]string, len(pq.elements))
+	for i, e := range pq.elements {
+		procs[i] = fmt.Sprintf("%d", e.process.id+1)
+	}
+	return QueueSnapshot{Name: pq.name, Procs: procs}
+}
@@ -17,10 +17,45 @@ const (
 	IO2
 )
 
+// IO - resource type of the n-th IO device, counting from 1
+func IO(n int) ResourceType {
+	return ResourceType(n)
+}
+
+func (t ResourceType) IsIO() bool {
+	return t > CPU
+}
+
+// Device - number of the IO device, counting from 1. 0 for CPU
+func (t ResourceType) Device() int {
+	return int(t)
+}
+
+func (t ResourceType) String() string {
+	if t == CPU {
+		return "CPU"
+	}
+	return fmt.Sprintf("IO%d", t.Device())
+}
+
 type Resourcer interface {
 	GetFree() (*Resource, error)
 	MustEvict(p *Process)
 	GetProcs() []*Process
+	// Resources - every single resource of the resourcer
+	Resources() []*Resource
+}
+
+// SwitchCounters - context switches of a process or a resource
+type SwitchCounters struct {
+	// Dispatches - assignments to a resource
+	Dispatches int
+	// Preemptions - evictions of a process in RUNNING state
+	Preemptions int
+	// IOYields - CPU left because the burst completed and the next one is IO
+	IOYields int
+	// Migrations - dispatches to a CPU other than the previous one. Counted on the destination CPU
+	Migrations int
 }
 
 type Resource struct {
@@ -29,20 +64,28 @@ type Resource struct {
 	resourceType    ResourceType
 	currentProc     *Process
 	ProcRunningTime int
+	counters        SwitchCounters
+	// switchCost - ticks CPU spends before running a process other than the previous one
+	switchCost int
+	lastProc   *Process
+	// switchTime - ticks the resource was occupied by context switches
+	switchTime int
 }
 
 func NewResource(name string, rType ResourceType) *Resource {
-	return &Resource{name, FREE, rType, nil, 0}
+	return &Resource{name, FREE, rType, nil, 0, SwitchCounters{}, 0, nil, 0}
 }
 
 type CpuPool struct {
 	cpus []*Resource
 }
 
-func NewCpuPool(n int) *CpuPool {
+// NewCpuPool - n CPUs, each spends switchCost ticks on context switch
+func NewCpuPool(n int, switchCost int) *CpuPool {
 	cpus := make([]*Resource, n, n)
 	for i := 0; i < n; i++ {
 		cpus[i] = NewResource(fmt.Sprintf("CPU%d", i+1), CPU)
+		cpus[i].switchCost = switchCost
 	}
 	return &CpuPool{cpus}
 }
@@ -60,15 +103,36 @@ func (r *Resource) AssignToFree(p *Process) error {
 	}
 	r.state = BUSY
 	r.currentProc = p
+	r.counters.Dispatches++
 	switch r.resourceType {
 	case CPU:
+		p.procStats.Dispatches++
+		if p.lastCpu != nil && p.lastCpu != r {
+			p.procStats.Migrations++
+			r.counters.Migrations++
+		}
+		p.lastCpu = r
+		// redispatch of the same process keeps its context, unfinished switch continues
+		if r.lastProc != p {
+			p.switchOverhead = r.switchCost
+		}
+		r.lastProc = p
 		p.AssignToCpu()
-	case IO1, IO2:
+	default:
 		p.AssignToIo()
 	}
 	return nil
 }
 
+func (r *Resource) Counters() SwitchCounters {
+	return r.counters
+}
+
+// SwitchTime - ticks spent on context switches. Timeline shows the process on the CPU during them
+func (r *Resource) SwitchTime() int {
+	return r.switchTime
+}
+
 func (r *Resource) Tick() {
 	if r.state == BUSY {
 		r.ProcRunningTime++
@@ -82,6 +146,23 @@ func (r *Resource) GetProcs() []*Process {
 	return []*Process{}
 }
 
+func (r *Resource) Resources() []*Resource {
+	return []*Resource{r}
+}
+
+func (r *Resource) Name() string {
+	return r.name
+}
+
+func (r *Resource) Type() ResourceType {
+	return r.resourceType
+}
+
+// CurrentProc - process running on the resource, nil if resource is free
+func (r *Resource) CurrentProc() *Process {
+	return r.currentProc
+}
+
 func (r *Resource) MustEvict(p *Process) {
 	if r.state == FREE {
 		panic(fmt.Sprintf("Can't evict process. Resource is free"))
@@ -91,6 +172,14 @@ func (r *Resource) MustEvict(p *Process) {
 	}
 	r.state = FREE
 	r.currentProc = nil
+	switch {
+	case p.state == RUNNING:
+		p.procStats.Preemptions++
+		r.counters.Preemptions++
+	case p.state == BLOCKED && r.resourceType == CPU:
+		p.procStats.IOYields++
+		r.counters.IOYields++
+	}
 	p.onEvict()
 }
 
@@ -117,6 +206,10 @@ func (cpu *CpuPool) GetProcs() []*Process {
 	return procs
 }
 
+func (cpu *CpuPool) Resources() []*Resource {
+	return cpu.cpus
+}
+
 func (cpu *CpuPool) MustEvict(p *Process) {
 	for _, res := range cpu.cpus {
 		if res.state == BUSY && res.currentProc.id == p.id {
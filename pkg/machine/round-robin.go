@@ -16,9 +16,15 @@ func (r *RoundRobin) ChooseToEvict(procs []*Process) []*Process {
 		if p.runningTime > r.quantum {
 			panic(fmt.Sprintf("Process %d has passed time %d, but quantum is %d", p.id, p.runningTime, r.quantum))
 		}
-		if p.clock.GetCurrentTick() % r.quantum == 0 || p.IsTaskCompleted() {
+		// a slice spent entirely on context switch is extended, otherwise quantum <= switch cost never makes progress
+		if (p.clock.GetCurrentTick()%r.quantum == 0 && p.runningTime > 0) || p.IsTaskCompleted() {
 			procsToEvict = append(procsToEvict, p)
 		}
 	}
 	return procsToEvict
 }
+
+// EvictReason - slices end on ticks divisible by quantum, so a process dispatched mid-slice runs less than quantum
+func (r *RoundRobin) EvictReason(p *Process) string {
+	return fmt.Sprintf("ran %d ticks until quantum boundary at tick %d, quantum is %d", p.runningTime, p.clock.GetCurrentTick(), r.quantum)
+}
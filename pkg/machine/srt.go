@@ -2,6 +2,7 @@ package machine
 
 import (
 	"errors"
+	"fmt"
 	"slices"
 	"sort"
 )
@@ -65,6 +66,10 @@ func getMinByRemainingTaskTime(elements []QueueElement) *Process {
 	return minProc
 }
 
+func (s *SchedulerSRT) EvictReason(p *Process) string {
+	return fmt.Sprintf("process with less than %d remaining ticks is ready", p.TaskRemainingTime())
+}
+
 func (s *SchedulerSRT) ChooseToEvict(procs []*Process) []*Process {
 	procsToEvict := make([]*Process, 0)
 	freeCpus := s.cpuCount - len(procs)
@@ -0,0 +1,52 @@
+package machine
+
+import "fmt"
+
+// TieBreak - order of processes entering CPU queue on the same tick
+type TieBreak int
+
+const (
+	TieBreakNewFirst       TieBreak = iota // arrivals, then preempted, then returning from IO
+	TieBreakReturningFirst                 // returning from IO, then preempted, then arrivals
+	TieBreakById                           // by process id
+	TieBreakByArrival                      // by arrival time, then by id
+)
+
+var tieBreakNames = []string{"new-first", "returning-first", "by-id", "by-arrival"}
+
+func (t TieBreak) String() string {
+	if int(t) < len(tieBreakNames) {
+		return tieBreakNames[t]
+	}
+	return "unknown"
+}
+
+func ParseTieBreak(name string) (TieBreak, error) {
+	for i, n := range tieBreakNames {
+		if n == name {
+			return TieBreak(i), nil
+		}
+	}
+	return 0, fmt.Errorf("unknown tie break %q, possible values: %v", name, tieBreakNames)
+}
+
+// entrantKind - how a process got into CPU queue. Order of values is the new-first order
+type entrantKind int
+
+const (
+	entrantNew entrantKind = iota
+	entrantPreempted
+	entrantReturning
+)
+
+// reason - why the process entered CPU queue, reported with enqueue event
+func (k entrantKind) reason() string {
+	switch k {
+	case entrantNew:
+		return "arrived"
+	case entrantPreempted:
+		return "evicted from CPU"
+	default:
+		return "io burst finished"
+	}
+}
@@ -0,0 +1,59 @@
+package report
+
+import (
+	"encoding/csv"
+	"io"
+	"strings"
+	"unicode"
+
+	m "github.com/Moleus/os-solver/pkg/machine"
+)
+
+// TimelineRows - process id on every CPU and IO device per tick, empty when the resource is idle
+func TimelineRows(header m.DumpState, timeline []m.DumpState) [][]string {
+	rows := [][]string{append(append([]string{header.Tick}, header.CpusState...), header.IoStates...)}
+	for _, state := range timeline {
+		row := append(append([]string{state.Tick}, state.CpusState...), state.IoStates...)
+		for i, v := range row {
+			if v == "-" {
+				row[i] = ""
+			}
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+// ColumnName - header of a table as a lowercase identifier, e.g. "Turnaround (Tr)" -> turnaround_tr
+func ColumnName(header string) string {
+	var sb strings.Builder
+	pendingSep := false
+	for _, r := range header {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			pendingSep = sb.Len() > 0
+			continue
+		}
+		if pendingSep {
+			sb.WriteRune('_')
+			pendingSep = false
+		}
+		sb.WriteRune(unicode.ToLower(r))
+	}
+	return sb.String()
+}
+
+// WriteCSV - rows as csv with header converted by ColumnName
+func WriteCSV(w io.Writer, rows [][]string) error {
+	if len(rows) == 0 {
+		return nil
+	}
+	header := make([]string, len(rows[0]))
+	for i, name := range rows[0] {
+		header[i] = ColumnName(name)
+	}
+	cw := csv.NewWriter(w)
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+	return cw.WriteAll(rows[1:])
+}
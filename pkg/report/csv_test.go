@@ -0,0 +1,39 @@
+package report
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestColumnName(t *testing.T) {
+	tests := []struct {
+		header string
+		want   string
+	}{
+		{"Turnaround (Tr)", "turnaround_tr"},
+		{"Tr/Ts", "tr_ts"},
+		{"Process", "process"},
+		{"Finish time", "finish_time"},
+		{"  Leading and trailing  ", "leading_and_trailing"},
+		{"CPU1", "cpu1"},
+		{"P95 of Tr/Ts", "p95_of_tr_ts"},
+		{"λ, arrivals/tick", "λ_arrivals_tick"},
+		{"()", ""},
+	}
+	for _, tt := range tests {
+		if got := ColumnName(tt.header); got != tt.want {
+			t.Errorf("ColumnName(%q) = %q, want %q", tt.header, got, tt.want)
+		}
+	}
+}
+
+func TestWriteCSV(t *testing.T) {
+	var sb strings.Builder
+	rows := [][]string{{"Process", "Tr/Ts"}, {"1", "1.5"}, {"2", "a, b"}}
+	if err := WriteCSV(&sb, rows); err != nil {
+		t.Fatal(err)
+	}
+	if want := "process,tr_ts\n1,1.5\n2,\"a, b\"\n"; sb.String() != want {
+		t.Errorf("got %q, want %q", sb.String(), want)
+	}
+}
@@ -0,0 +1,242 @@
+package report
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/Moleus/os-solver/pkg/gantt"
+	"github.com/Moleus/os-solver/pkg/sim"
+)
+
+// procState - what a process was doing during a tick
+type procState struct {
+	Class string
+	Title string
+}
+
+type htmlRun struct {
+	Name      string
+	Info      [][]string
+	Gantt     template.HTML
+	Queues    template.HTML
+	States    [][]procState
+	ProcStats [][]string
+	Metrics   [][]string
+	Resources [][]string
+}
+
+type htmlPage struct {
+	Title      string
+	Runs       []htmlRun
+	Comparison [][]string
+}
+
+// WriteHTML - offline page with Gantt chart, queue lengths, process states, stats and metrics of every result.
+// Several results also get a comparison table of aggregate metrics
+func WriteHTML(w io.Writer, title string, results []sim.Result) error {
+	page := htmlPage{Title: title}
+	names := ResultNames(results)
+	for i, res := range results {
+		run, err := newHTMLRun(names[i], res)
+		if err != nil {
+			return err
+		}
+		page.Runs = append(page.Runs, run)
+	}
+	if len(results) > 1 {
+		page.Comparison = ComparisonRows(names, results)
+	}
+	return htmlTemplate.Execute(w, page)
+}
+
+// ResultNames - algorithm of every result, numbered when the same algorithm is used several times
+func ResultNames(results []sim.Result) []string {
+	counts := make(map[string]int)
+	for _, res := range results {
+		counts[res.Config.Algorithm]++
+	}
+	seen := make(map[string]int)
+	names := make([]string, len(results))
+	for i, res := range results {
+		name := res.Config.Algorithm
+		if counts[name] > 1 {
+			seen[name]++
+			name = fmt.Sprintf("%s #%d", name, seen[name])
+		}
+		names[i] = name
+	}
+	return names
+}
+
+// ComparisonRows - aggregate metrics with a column per result
+func ComparisonRows(names []string, results []sim.Result) [][]string {
+	rows := [][]string{append([]string{"Metric"}, names...)}
+	for _, metric := range sim.SweepMetrics {
+		row := []string{metric.Name}
+		for _, res := range results {
+			row = append(row, fmt.Sprintf("%f", metric.Value(res.Metrics)))
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+func newHTMLRun(name string, res sim.Result) (htmlRun, error) {
+	var svg bytes.Buffer
+	chart := gantt.ByResource(res.Header, res.Timeline)
+	if err := gantt.WriteSVG(&svg, chart, res.Events, gantt.SVGOptions{}); err != nil {
+		return htmlRun{}, err
+	}
+	return htmlRun{
+		Name:      name,
+		Info:      RunInfo(res.Config),
+		Gantt:     template.HTML(svg.String()),
+		Queues:    template.HTML(queueLengthsSVG(res)),
+		States:    procStates(res),
+		ProcStats: ProcStatsRows(res.Procs),
+		Metrics:   MetricsRows(res.Metrics),
+		Resources: ResourceRows(res.Metrics),
+	}, nil
+}
+
+// procStates - state of every process on every tick of the timeline
+func procStates(res sim.Result) [][]procState {
+	states := make([][]procState, len(res.Procs))
+	for id := range states {
+		states[id] = make([]procState, len(res.Timeline))
+	}
+	resources := append(append([]string{}, res.Header.CpusState...), res.Header.IoStates...)
+	for tick, state := range res.Timeline {
+		for i, proc := range append(append([]string{}, state.CpusState...), state.IoStates...) {
+			id, err := strconv.Atoi(proc)
+			if err != nil {
+				continue
+			}
+			class, doing := "io", "IO on"
+			if i < len(state.CpusState) {
+				class, doing = "cpu", "running on"
+			}
+			states[id-1][tick] = procState{class, fmt.Sprintf("P%d, tick %d: %s %s", id, tick, doing, resources[i])}
+		}
+		for qi, q := range state.Queues {
+			class := "blocked"
+			if qi == 0 {
+				class = "ready"
+			}
+			for pos, proc := range q.Procs {
+				id, err := strconv.Atoi(proc)
+				if err != nil {
+					continue
+				}
+				states[id-1][tick] = procState{class, fmt.Sprintf("P%d, tick %d: waiting in %s queue, position %d of %d", id, tick, q.Name, pos+1, len(q.Procs))}
+			}
+		}
+		for id, stats := range res.Procs {
+			if states[id][tick].Class != "" {
+				continue
+			}
+			switch {
+			case tick < stats.EntranceTime:
+				states[id][tick] = procState{"", fmt.Sprintf("P%d, tick %d: not arrived", id+1, tick)}
+			case tick > stats.ExitTime:
+				states[id][tick] = procState{"", fmt.Sprintf("P%d, tick %d: finished", id+1, tick)}
+			}
+		}
+	}
+	return states
+}
+
+// queueLengthsSVG - step line of every queue length over ticks
+func queueLengthsSVG(res sim.Result) string {
+	const (
+		height    = 120
+		left      = 40
+		tickWidth = 3
+	)
+	maxLength := 1
+	for _, state := range res.Timeline {
+		for _, q := range state.Queues {
+			maxLength = max(maxLength, len(q.Procs))
+		}
+	}
+	colors := gantt.Palette(len(res.Header.Queues))
+	width := left + len(res.Timeline)*tickWidth + 20
+	y := func(length int) int {
+		return 10 + height - length*height/maxLength
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" font-family="sans-serif" font-size="12">`, width, height+50)
+	fmt.Fprintf(&sb, `<line x1="%d" y1="%d" x2="%d" y2="%d" stroke="black"/>`, left, y(0), width-20, y(0))
+	for length := 0; length <= maxLength; length++ {
+		fmt.Fprintf(&sb, `<text x="%d" y="%d" text-anchor="end" dominant-baseline="middle">%d</text>`, left-6, y(length), length)
+	}
+	for qi, q := range res.Header.Queues {
+		points := make([]string, 0, 2*len(res.Timeline))
+		for tick, state := range res.Timeline {
+			length := len(state.Queues[qi].Procs)
+			points = append(points, fmt.Sprintf("%d,%d %d,%d", left+tick*tickWidth, y(length), left+(tick+1)*tickWidth, y(length)))
+		}
+		fmt.Fprintf(&sb, `<polyline points="%s" fill="none" stroke="%s" stroke-width="2"><title>%s queue</title></polyline>`,
+			strings.Join(points, " "), colors[qi], template.HTMLEscapeString(q.Name))
+		fmt.Fprintf(&sb, `<rect x="%d" y="%d" width="12" height="12" fill="%s"/><text x="%d" y="%d">%s</text>`,
+			left+qi*90, height+30, colors[qi], left+qi*90+16, height+40, template.HTMLEscapeString(q.Name))
+	}
+	sb.WriteString(`</svg>`)
+	return sb.String()
+}
+
+var htmlTemplate = template.Must(template.New("report").Funcs(template.FuncMap{
+	"inc": func(i int) int { return i + 1 },
+}).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+<style>
+body { font-family: sans-serif; margin: 20px; color: #222; }
+h2 { border-bottom: 1px solid #ccc; padding-bottom: 4px; }
+table.data { border-collapse: collapse; margin: 8px 0 16px; }
+table.data th, table.data td { border: 1px solid #ccc; padding: 2px 6px; text-align: right; }
+table.data th { background: #f3f3f3; }
+table.data td:first-child { text-align: left; }
+.scroll { overflow-x: auto; }
+table.states { border-collapse: collapse; }
+table.states td { width: 4px; height: 14px; padding: 0; border: 0; }
+table.states th { text-align: right; padding-right: 6px; font-weight: normal; }
+td.cpu { background: #4caf50; }
+td.io { background: #2196f3; }
+td.ready { background: #ffc107; }
+td.blocked { background: #ff7043; }
+.legend span { display: inline-block; width: 12px; height: 12px; margin: 0 4px 0 12px; vertical-align: middle; }
+</style>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+{{with .Comparison}}
+<h2>Comparison</h2>
+<table class="data">{{range $i, $row := .}}<tr>{{range $row}}{{if eq $i 0}}<th>{{.}}</th>{{else}}<td>{{.}}</td>{{end}}{{end}}</tr>{{end}}</table>
+{{end}}
+{{range .Runs}}
+<h2>{{.Name}}</h2>
+<table class="data">{{range .Info}}<tr>{{range .}}<td>{{.}}</td>{{end}}</tr>{{end}}</table>
+<h3>Gantt chart</h3>
+<div class="scroll">{{.Gantt}}</div>
+<h3>Process states</h3>
+<p class="legend"><span style="background:#4caf50"></span>CPU<span style="background:#2196f3"></span>IO<span style="background:#ffc107"></span>ready queue<span style="background:#ff7043"></span>IO queue</p>
+<div class="scroll"><table class="states">{{range $id, $states := .States}}<tr><th>P{{inc $id}}</th>{{range $states}}<td{{with .Class}} class="{{.}}"{{end}}{{with .Title}} title="{{.}}"{{end}}></td>{{end}}</tr>{{end}}</table></div>
+<h3>Queue lengths</h3>
+<div class="scroll">{{.Queues}}</div>
+<h3>Processes</h3>
+<table class="data">{{range $i, $row := .ProcStats}}<tr>{{range $row}}{{if eq $i 0}}<th>{{.}}</th>{{else}}<td>{{.}}</td>{{end}}{{end}}</tr>{{end}}</table>
+<h3>Metrics</h3>
+<table class="data">{{range .Metrics}}<tr>{{range .}}<td>{{.}}</td>{{end}}</tr>{{end}}</table>
+<table class="data">{{range $i, $row := .Resources}}<tr>{{range $row}}{{if eq $i 0}}<th>{{.}}</th>{{else}}<td>{{.}}</td>{{end}}{{end}}</tr>{{end}}</table>
+{{end}}
+</body>
+</html>
+`))
@@ -0,0 +1,64 @@
+package report
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/Moleus/os-solver/pkg/gantt"
+	"github.com/Moleus/os-solver/pkg/sim"
+)
+
+// WriteLaTeX - fragment with the Gantt chart as a TikZ figure and stats as booktabs tables,
+// needs tikz, booktabs and graphicx packages
+func WriteLaTeX(w io.Writer, res sim.Result, chart gantt.Chart) error {
+	title := gantt.EscapeLaTeX(res.Title())
+	var sb strings.Builder
+	sb.WriteString("% \\usepackage{tikz, booktabs, graphicx}\n")
+	sb.WriteString("\\begin{figure}[htbp]\n\\centering\n")
+	if err := gantt.WriteTikZ(&sb, chart); err != nil {
+		return err
+	}
+	fmt.Fprintf(&sb, "\\caption{Gantt chart, %s}\n\\end{figure}\n\n", title)
+
+	tables := []struct {
+		caption string
+		rows    [][]string
+	}{
+		{"Settings", withHeader("Setting", RunInfo(res.Config))},
+		{"Processes", ProcStatsRows(res.Procs)},
+		{"Metrics", withHeader("Metric", MetricsRows(res.Metrics))},
+		{"Resources", ResourceRows(res.Metrics)},
+	}
+	for _, t := range tables {
+		sb.WriteString("\\begin{table}[htbp]\n\\centering\n")
+		fmt.Fprintf(&sb, "\\caption{%s, %s}\n", t.caption, title)
+		// stats of processes have too many columns for the page
+		sb.WriteString("\\resizebox{\\ifdim\\width>\\linewidth\\linewidth\\else\\width\\fi}{!}{%\n")
+		writeLaTeXTable(&sb, t.rows)
+		sb.WriteString("}\n\\end{table}\n\n")
+	}
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// writeLaTeXTable - booktabs tabular, the first row is the header
+func writeLaTeXTable(sb *strings.Builder, rows [][]string) {
+	if len(rows) == 0 {
+		return
+	}
+	writeRow := func(row []string) {
+		cells := make([]string, len(row))
+		for i, v := range row {
+			cells[i] = gantt.EscapeLaTeX(v)
+		}
+		fmt.Fprintf(sb, "%s \\\\\n", strings.Join(cells, " & "))
+	}
+	fmt.Fprintf(sb, "\\begin{tabular}{l%s}\n\\toprule\n", strings.Repeat("r", len(rows[0])-1))
+	writeRow(rows[0])
+	sb.WriteString("\\midrule\n")
+	for _, row := range rows[1:] {
+		writeRow(row)
+	}
+	sb.WriteString("\\bottomrule\n\\end{tabular}\n")
+}
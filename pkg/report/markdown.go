@@ -0,0 +1,59 @@
+package report
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/Moleus/os-solver/pkg/gantt"
+	"github.com/Moleus/os-solver/pkg/sim"
+)
+
+// WriteMarkdown - GitHub flavoured section with settings, Gantt chart as a code block and stats tables
+func WriteMarkdown(w io.Writer, res sim.Result, chart gantt.Chart, opts gantt.ASCIIOptions) error {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "## %s\n\n", res.Title())
+	writeMarkdownTable(&sb, withHeader("Setting", RunInfo(res.Config)))
+	sb.WriteString("\n### Gantt chart\n\n```\n")
+	if err := gantt.WriteASCII(&sb, chart, opts); err != nil {
+		return err
+	}
+	sb.WriteString("```\n\n### Processes\n\n")
+	writeMarkdownTable(&sb, ProcStatsRows(res.Procs))
+	sb.WriteString("\n### Metrics\n\n")
+	writeMarkdownTable(&sb, withHeader("Metric", MetricsRows(res.Metrics)))
+	sb.WriteString("\n")
+	writeMarkdownTable(&sb, ResourceRows(res.Metrics))
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// writeMarkdownTable - the first row is the header, values except the first column are right aligned
+func writeMarkdownTable(sb *strings.Builder, rows [][]string) {
+	if len(rows) == 0 {
+		return
+	}
+	escape := strings.NewReplacer("|", `\|`)
+	writeRow := func(row []string) {
+		sb.WriteString("|")
+		for _, v := range row {
+			fmt.Fprintf(sb, " %s |", escape.Replace(v))
+		}
+		sb.WriteString("\n")
+	}
+	writeRow(rows[0])
+	align := make([]string, len(rows[0]))
+	for i := range align {
+		align[i] = "---:"
+	}
+	align[0] = "---"
+	writeRow(align)
+	for _, row := range rows[1:] {
+		writeRow(row)
+	}
+}
+
+// withHeader - name/value rows prefixed with a header row
+func withHeader(name string, rows [][]string) [][]string {
+	return append([][]string{{name, "Value"}}, rows...)
+}
@@ -0,0 +1,238 @@
+// Package report builds tables of a simulation result shared by text, csv, html and xlsx outputs.
+// Every table is a header row followed by value rows
+package report
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	m "github.com/Moleus/os-solver/pkg/machine"
+	"github.com/Moleus/os-solver/pkg/sim"
+)
+
+// ProcStatsRows - per-process stats, the columns of procStats file
+func ProcStatsRows(procs []m.ProcStats) [][]string {
+	rows := [][]string{{"Process", "Arrival", "Service", "Waiting", "Finish time", "Turnaround (Tr)", "Tr/Ts", "Response", "Ready wait", "IO wait", "CPU service", "IO service", "Dispatches", "Preemptions", "IO yields", "Migrations", "Longest ready wait", "Switch overhead"}}
+	for _, stats := range procs {
+		rows = append(rows, []string{
+			strconv.Itoa(stats.ProcId + 1),
+			strconv.Itoa(stats.EntranceTime),
+			strconv.Itoa(stats.ServiceTime),
+			strconv.Itoa(stats.ReadyOrBlockedTime),
+			strconv.Itoa(stats.ExitTime),
+			strconv.Itoa(stats.TurnaroundTime),
+			fmt.Sprintf("%f", sim.NormalizedTurnaround(stats)),
+			strconv.Itoa(stats.ResponseTime),
+			strconv.Itoa(stats.ReadyWaitTime),
+			strconv.Itoa(stats.IOWaitTime),
+			strconv.Itoa(stats.CPUServiceTime),
+			strconv.Itoa(stats.IOServiceTime),
+			strconv.Itoa(stats.Dispatches),
+			strconv.Itoa(stats.Preemptions),
+			strconv.Itoa(stats.IOYields),
+			strconv.Itoa(stats.Migrations),
+			strconv.Itoa(stats.LongestReadyWait),
+			strconv.Itoa(stats.SwitchOverheadTime),
+		})
+	}
+	return rows
+}
+
+// TaskStatsRows - per-burst stats
+func TaskStatsRows(tasks []m.TaskStats) [][]string {
+	rows := [][]string{{"Process", "Burst", "Resource", "Duration", "Ready", "Start", "Waiting", "Preemptions", "Switch overhead", "Finish"}}
+	for _, t := range tasks {
+		rows = append(rows, []string{
+			strconv.Itoa(t.ProcId + 1),
+			strconv.Itoa(t.TaskIndex + 1),
+			t.ResourceType.String(),
+			strconv.Itoa(t.Duration),
+			strconv.Itoa(t.ReadyTime),
+			strconv.Itoa(t.StartTime),
+			strconv.Itoa(t.WaitTime),
+			strconv.Itoa(t.Preemptions),
+			strconv.Itoa(t.SwitchOverhead),
+			strconv.Itoa(t.FinishTime),
+		})
+	}
+	return rows
+}
+
+// QueueRows - per tick length and contents of every queue
+func QueueRows(header m.DumpState, timeline []m.DumpState) [][]string {
+	headerRow := []string{"Tick"}
+	for _, q := range header.Queues {
+		headerRow = append(headerRow, q.Name, q.Name+" procs")
+	}
+	rows := [][]string{headerRow}
+	for _, state := range timeline {
+		row := []string{state.Tick}
+		for _, q := range state.Queues {
+			procs := strings.Join(q.Procs, ",")
+			if procs == "" {
+				procs = "-"
+			}
+			row = append(row, strconv.Itoa(len(q.Procs)), procs)
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+// ResourceRows - busy time and switch counters of every CPU and IO device
+func ResourceRows(metrics sim.Metrics) [][]string {
+	rows := [][]string{{"Resource", "Busy", "Switch", "Idle", "Utilisation", "Dispatches", "Preemptions", "IO yields", "Migrations"}}
+	for _, r := range metrics.Resources {
+		rows = append(rows, []string{
+			r.Name,
+			strconv.Itoa(r.BusyTime),
+			strconv.Itoa(r.SwitchTime),
+			strconv.Itoa(r.IdleTime),
+			fmt.Sprintf("%f", r.Utilisation),
+			strconv.Itoa(r.Dispatches),
+			strconv.Itoa(r.Preemptions),
+			strconv.Itoa(r.IOYields),
+			strconv.Itoa(r.Migrations),
+		})
+	}
+	return rows
+}
+
+// RunInfo - settings which affect results, printed after stats
+func RunInfo(config sim.Config) [][]string {
+	return [][]string{
+		{"Algorithm", config.Algorithm},
+		{"Tie-break", config.TieBreak.String()},
+		{"Context switch cost", strconv.Itoa(config.SwitchCost)},
+		{"Starvation threshold", strconv.Itoa(config.StarvationThreshold)},
+	}
+}
+
+// MetricsRows - aggregate metrics as name/value rows
+func MetricsRows(metrics sim.Metrics) [][]string {
+	rows := [][]string{
+		{"Makespan", strconv.Itoa(metrics.Makespan)},
+		{"Throughput", fmt.Sprintf("%f", metrics.Throughput)},
+		{"CPU utilisation", fmt.Sprintf("%f", metrics.CPUUtilisation)},
+		{"CPU idle time", strconv.Itoa(metrics.CPUIdleTime)},
+		{"CPU switch time", strconv.Itoa(metrics.CPUSwitchTime)},
+	}
+	for _, r := range metrics.Resources {
+		rows = append(rows, []string{r.Name + " utilisation", fmt.Sprintf("%f", r.Utilisation)})
+	}
+	summaries := []struct {
+		name    string
+		summary sim.Summary
+	}{
+		{"Turnaround", metrics.Turnaround},
+		{"Waiting", metrics.Waiting},
+		{"Tr/Ts", metrics.NormalizedTurnaround},
+		{"Response", metrics.Response},
+	}
+	for _, s := range summaries {
+		rows = append(rows,
+			[]string{s.name + " mean", fmt.Sprintf("%f", s.summary.Mean)},
+			[]string{s.name + " median", fmt.Sprintf("%f", s.summary.Median)},
+			[]string{s.name + " p95", fmt.Sprintf("%f", s.summary.P95)},
+		)
+	}
+	starved := make([]string, len(metrics.Starved))
+	for i, id := range metrics.Starved {
+		starved[i] = strconv.Itoa(id + 1)
+	}
+	if len(starved) == 0 {
+		starved = []string{"-"}
+	}
+	rows = append(rows,
+		[]string{"Jain fairness (Tr/Ts)", fmt.Sprintf("%f", metrics.Fairness)},
+		[]string{"Starved processes", strings.Join(starved, ",")},
+	)
+	for _, q := range metrics.Queues {
+		rows = append(rows,
+			[]string{q.Name + " queue avg length (L)", fmt.Sprintf("%f", q.AvgLength)},
+			[]string{q.Name + " queue max length", strconv.Itoa(q.MaxLength)},
+			[]string{q.Name + " queue arrival rate (λ)", fmt.Sprintf("%f", q.ArrivalRate)},
+			[]string{q.Name + " queue avg wait (W)", fmt.Sprintf("%f", q.AvgWait)},
+			[]string{q.Name + " queue λW", fmt.Sprintf("%f", q.LittleLength)},
+		)
+	}
+	return rows
+}
+
+// SweepRows - swept values and aggregate metrics of every point
+func SweepRows(params []sim.SweepParam, points []sim.SweepPoint) [][]string {
+	header := make([]string, 0, len(params)+len(sim.SweepMetrics))
+	for _, param := range params {
+		header = append(header, param.Name)
+	}
+	for _, metric := range sim.SweepMetrics {
+		header = append(header, metric.Name)
+	}
+	rows := [][]string{header}
+	for _, point := range points {
+		row := make([]string, 0, len(header))
+		for _, v := range point.Values {
+			row = append(row, strconv.Itoa(v))
+		}
+		for _, metric := range sim.SweepMetrics {
+			row = append(row, fmt.Sprintf("%f", metric.Value(point.Metrics)))
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+// BestSweepRows - setting with the best value of every metric
+func BestSweepRows(params []sim.SweepParam, points []sim.SweepPoint) [][]string {
+	header := []string{"Best by", "Value"}
+	for _, param := range params {
+		header = append(header, param.Name)
+	}
+	rows := [][]string{header}
+	for i, idx := range sim.BestSweepPoints(points) {
+		metric := sim.SweepMetrics[i]
+		row := []string{metric.Name, fmt.Sprintf("%f", metric.Value(points[idx].Metrics))}
+		for _, v := range points[idx].Values {
+			row = append(row, strconv.Itoa(v))
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+// EstimateRows - mean and 95% confidence interval of every metric per algorithm
+func EstimateRows(result sim.MonteCarloResult) [][]string {
+	rows := [][]string{{"Algorithm", "Metric", "Mean", "Std dev", "CI 95% low", "CI 95% high"}}
+	for _, estimate := range result.Estimates {
+		for i, metric := range sim.SweepMetrics {
+			e := estimate.Metrics[i]
+			rows = append(rows, []string{
+				estimate.Algorithm,
+				metric.Name,
+				fmt.Sprintf("%f", e.Mean),
+				fmt.Sprintf("%f", e.StdDev),
+				fmt.Sprintf("%f", e.Low),
+				fmt.Sprintf("%f", e.High),
+			})
+		}
+	}
+	return rows
+}
+
+// WinRateRows - one row per metric and ordered pair of algorithms with share of workloads
+// where the algorithm beat the opponent
+func WinRateRows(result sim.MonteCarloResult) [][]string {
+	rows := [][]string{{"Metric", "Algorithm", "Opponent", "Win rate"}}
+	for i, metric := range sim.SweepMetrics {
+		for a, estimate := range result.Estimates {
+			for b, opponent := range result.Estimates {
+				if a == b {
+					continue
+				}
+				rows = append(rows, []string{metric.Name, estimate.Algorithm, opponent.Algorithm, fmt.Sprintf("%f", result.WinRates[i][a][b])})
+			}
+		}
+	}
+	return rows
+}
@@ -0,0 +1,88 @@
+package sim
+
+import (
+	"fmt"
+
+	m "github.com/Moleus/os-solver/pkg/machine"
+)
+
+func init() {
+	Register(Algorithm{
+		Name:        "fcfs",
+		Description: "First come first served, nonpreemptive",
+		New: func(env SchedulerEnv) (m.Evictor, m.SelectionFunction, error) {
+			return m.NewNonPreemptive(), m.NewSelectionFIFO(), nil
+		},
+	})
+	Register(Algorithm{
+		Name:        "rr1",
+		Description: "Round robin with quantum 1",
+		New:         fixedRoundRobin(1),
+	})
+	Register(Algorithm{
+		Name:        "rr4",
+		Description: "Round robin with quantum 4",
+		New:         fixedRoundRobin(4),
+	})
+	Register(Algorithm{
+		Name:        "rr",
+		Description: "Round robin with configurable quantum",
+		Params: []ParamSpec{
+			{Name: "quantum", Kind: IntParam, Default: "4", Description: "time slice in ticks"},
+		},
+		New: func(env SchedulerEnv) (m.Evictor, m.SelectionFunction, error) {
+			quantum := env.Params.Int("quantum")
+			if quantum < 1 {
+				return nil, nil, fmt.Errorf("round robin quantum must be positive, got %d", quantum)
+			}
+			return m.NewRoundRobinEvictor(quantum), m.NewSelectionFIFO(), nil
+		},
+	})
+	Register(Algorithm{
+		Name:        "spn",
+		Description: "Shortest process next, nonpreemptive",
+		New: func(env SchedulerEnv) (m.Evictor, m.SelectionFunction, error) {
+			return m.NewNonPreemptive(), m.NewSelectionSPN(), nil
+		},
+	})
+	Register(Algorithm{
+		Name:        "srt",
+		Description: "Shortest remaining time, preempts when a shorter process arrives",
+		New: func(env SchedulerEnv) (m.Evictor, m.SelectionFunction, error) {
+			srt := m.NewSchedulerSRT(env.Queue, env.CPUs)
+			return srt, srt, nil
+		},
+	})
+	Register(Algorithm{
+		Name:        "hrrn",
+		Description: "Highest response ratio next, nonpreemptive",
+		New: func(env SchedulerEnv) (m.Evictor, m.SelectionFunction, error) {
+			return m.NewNonPreemptive(), m.NewSelectionHRRN(), nil
+		},
+	})
+	Register(Algorithm{
+		Name:        "expr",
+		Description: "Custom policy defined by expressions over w, e, s, remaining, priority, arrival and run",
+		Params: []ParamSpec{
+			{Name: "select", Kind: StringParam, Default: "min(arrival)", Description: "objective max(expr) or min(expr), e.g. max((w+s)/s)"},
+			{Name: "preempt", Kind: StringParam, Default: "", Description: "rule to preempt running process, may use score and best, e.g. best < score"},
+		},
+		New: func(env SchedulerEnv) (m.Evictor, m.SelectionFunction, error) {
+			selection, err := m.NewSelectionExpr(env.Params["select"])
+			if err != nil {
+				return nil, nil, err
+			}
+			evictor, err := m.NewExprEvictor(selection, env.Params["preempt"], env.Queue)
+			if err != nil {
+				return nil, nil, err
+			}
+			return evictor, selection, nil
+		},
+	})
+}
+
+func fixedRoundRobin(quantum int) SchedulerFactory {
+	return func(env SchedulerEnv) (m.Evictor, m.SelectionFunction, error) {
+		return m.NewRoundRobinEvictor(quantum), m.NewSelectionFIFO(), nil
+	}
+}
@@ -0,0 +1,36 @@
+package sim
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// TestExprReproducesSRT - README claims -select "min(remaining)" -preempt "best < score" behaves like srt
+func TestExprReproducesSRT(t *testing.T) {
+	workload, err := ParseWorkload(strings.NewReader(testWorkload))
+	if err != nil {
+		t.Fatal(err)
+	}
+	config := DefaultConfig()
+	config.CPUs = 1
+	config.Algorithm = "srt"
+	srt, err := Run(context.Background(), config, workload)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	config.Algorithm = "expr"
+	config.Params = Params{"select": "min(remaining)", "preempt": "best < score"}
+	expr, err := Run(context.Background(), config, workload)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(expr.Timeline, srt.Timeline) {
+		t.Errorf("timeline of expr differs from srt")
+	}
+	if !reflect.DeepEqual(expr.Procs, srt.Procs) {
+		t.Errorf("process stats of expr differ from srt")
+	}
+}
@@ -0,0 +1,91 @@
+package sim
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"strconv"
+
+	m "github.com/Moleus/os-solver/pkg/machine"
+)
+
+// Params - tunables of scheduling algorithms by name, see Algorithm.Params.
+// Algorithms ignore params they don't declare
+type Params map[string]string
+
+// Int - value of int param. Kinds are checked before a factory is called, so bad values are 0
+func (p Params) Int(name string) int {
+	v, _ := strconv.Atoi(p[name])
+	return v
+}
+
+// ArrivalPolicy - decides when a process enters the system
+type ArrivalPolicy interface {
+	ArrivalTime(procId int) int
+}
+
+// FixedInterval - process i arrives at tick i*Interval
+type FixedInterval struct {
+	Interval int
+}
+
+func NewFixedIntervalArrival(interval int) ArrivalPolicy {
+	return FixedInterval{Interval: interval}
+}
+
+func (a FixedInterval) ArrivalTime(procId int) int {
+	return procId * a.Interval
+}
+
+type Config struct {
+	CPUs int
+	// Devices - count of IO devices. Tasks of a workload can use IO1..IO{Devices}
+	Devices   int
+	Algorithm string
+	Params    Params
+	Arrival   ArrivalPolicy
+	// TieBreak - order of processes entering CPU queue on the same tick
+	TieBreak m.TieBreak
+	// SwitchCost - ticks CPU spends on context switch before running a process other than the previous one
+	SwitchCost int
+	// StarvationThreshold - process is starved if it continuously waited in ready queue longer. 0 disables detection
+	StarvationThreshold int
+
+	// Observer - optional subscriber to events of the run
+	Observer m.Observer
+
+	// LogOutput - destination of the simulation log. Logs are discarded if nil
+	LogOutput io.Writer
+	LogLevel  slog.Level
+}
+
+// DefaultConfig - 4 CPUs, 2 IO devices, fcfs, processes arrive every 2 ticks
+func DefaultConfig() Config {
+	return Config{
+		CPUs:      4,
+		Devices:   2,
+		Algorithm: "fcfs",
+		Params:    Params{},
+		Arrival:   NewFixedIntervalArrival(2),
+		LogLevel:  slog.LevelInfo,
+
+		StarvationThreshold: 50,
+	}
+}
+
+func (c Config) Validate() error {
+	if c.CPUs < 1 {
+		return fmt.Errorf("at least one CPU is required, got %d", c.CPUs)
+	}
+	if c.Devices < 1 {
+		return fmt.Errorf("at least one IO device is required, got %d", c.Devices)
+	}
+	if c.SwitchCost < 0 {
+		return fmt.Errorf("context switch cost can't be negative, got %d", c.SwitchCost)
+	}
+	if c.Arrival == nil {
+		return errors.New("arrival policy is not set")
+	}
+	return nil
+}
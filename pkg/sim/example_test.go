@@ -0,0 +1,57 @@
+package sim_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Moleus/os-solver/pkg/machine"
+	"github.com/Moleus/os-solver/pkg/sim"
+)
+
+// selectionLIFO - picks the process which entered the queue last
+type selectionLIFO struct{}
+
+func (selectionLIFO) Select(queue *machine.ProcQueue) (*machine.Process, error) {
+	elements := queue.GetQueueElements()
+	if len(elements) == 0 {
+		return nil, errors.New("queue is empty")
+	}
+	last := elements[0]
+	for _, e := range elements {
+		if e.EnterTime() >= last.EnterTime() {
+			last = e
+		}
+	}
+	return queue.Pick(last.Process())
+}
+
+func ExampleRegister() {
+	sim.Register(sim.Algorithm{
+		Name:        "lifo",
+		Description: "Last come first served",
+		New: func(env sim.SchedulerEnv) (machine.Evictor, machine.SelectionFunction, error) {
+			return machine.NewNonPreemptive(), selectionLIFO{}, nil
+		},
+	})
+
+	workload, err := sim.ParseWorkload(strings.NewReader("CPU(4);IO1(2);CPU(2);\nCPU(3);\nCPU(1);\n"))
+	if err != nil {
+		panic(err)
+	}
+	config := sim.DefaultConfig()
+	config.CPUs = 1
+	config.Algorithm = "lifo"
+	result, err := sim.Run(context.Background(), config, workload)
+	if err != nil {
+		panic(err)
+	}
+	for _, p := range result.Procs {
+		fmt.Printf("P%d finished at %d\n", p.ProcId+1, p.ExitTime)
+	}
+	// Output:
+	// P1 finished at 9
+	// P2 finished at 7
+	// P3 finished at 4
+}
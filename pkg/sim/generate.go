@@ -0,0 +1,186 @@
+package sim
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"strconv"
+	"strings"
+
+	m "github.com/Moleus/os-solver/pkg/machine"
+)
+
+// Distribution - source of positive integer samples
+type Distribution interface {
+	Sample(r *rand.Rand) int
+	String() string
+}
+
+// Uniform - integers from Min to Max inclusive
+type Uniform struct {
+	Min, Max int
+}
+
+func (d Uniform) Sample(r *rand.Rand) int {
+	return d.Min + r.Intn(d.Max-d.Min+1)
+}
+
+func (d Uniform) String() string {
+	if d.Min == d.Max {
+		return strconv.Itoa(d.Min)
+	}
+	return fmt.Sprintf("%d..%d", d.Min, d.Max)
+}
+
+// Exponential - exponentially distributed with Mean, rounded up so samples are at least 1
+type Exponential struct {
+	Mean float64
+}
+
+func (d Exponential) Sample(r *rand.Rand) int {
+	return int(math.Ceil(r.ExpFloat64() * d.Mean))
+}
+
+func (d Exponential) String() string {
+	return fmt.Sprintf("exp(%g)", d.Mean)
+}
+
+// ParseDistribution - parses constant 4, uniform 2..12 or exponential exp(8)
+func ParseDistribution(s string) (Distribution, error) {
+	s = strings.TrimSpace(s)
+	if strings.HasPrefix(s, "exp(") && strings.HasSuffix(s, ")") {
+		mean, err := strconv.ParseFloat(s[len("exp("):len(s)-1], 64)
+		if err != nil || mean <= 0 {
+			return nil, fmt.Errorf("exponential mean must be a positive number in %q", s)
+		}
+		return Exponential{Mean: mean}, nil
+	}
+	minStr, maxStr, isRange := strings.Cut(s, "..")
+	if !isRange {
+		maxStr = minStr
+	}
+	min, errMin := strconv.Atoi(minStr)
+	max, errMax := strconv.Atoi(maxStr)
+	if errMin != nil || errMax != nil {
+		return nil, fmt.Errorf("expected n, min..max or exp(mean), got %q", s)
+	}
+	if min < 1 || max < min {
+		return nil, fmt.Errorf("distribution %q must produce positive values", s)
+	}
+	return Uniform{Min: min, Max: max}, nil
+}
+
+// WorkloadSpec - shape of random workloads. Each process alternates CPU and IO bursts
+// like lab variants do: CPU, IO, CPU, IO...
+type WorkloadSpec struct {
+	Processes int
+	// Bursts - number of CPU bursts of a process, each one is followed by IO burst
+	Bursts Distribution
+	CPU    Distribution
+	IO     Distribution
+}
+
+// DefaultWorkloadSpec - close to lab variants: 6 processes of 4..8 CPU bursts
+func DefaultWorkloadSpec() WorkloadSpec {
+	return WorkloadSpec{
+		Processes: 6,
+		Bursts:    Uniform{4, 8},
+		CPU:       Uniform{2, 12},
+		IO:        Uniform{10, 20},
+	}
+}
+
+// ParseWorkloadSpec - overrides fields of DefaultWorkloadSpec from comma separated
+// procs=n, bursts=dist, cpu=dist and io=dist, e.g. "procs=8,cpu=exp(6)"
+func ParseWorkloadSpec(s string) (WorkloadSpec, error) {
+	spec := DefaultWorkloadSpec()
+	if strings.TrimSpace(s) == "" {
+		return spec, nil
+	}
+	for _, field := range splitSpecFields(s) {
+		name, value, ok := strings.Cut(field, "=")
+		if !ok {
+			return WorkloadSpec{}, fmt.Errorf("expected name=value, got %q", field)
+		}
+		name = strings.TrimSpace(name)
+		if name == "procs" {
+			n, err := strconv.Atoi(strings.TrimSpace(value))
+			if err != nil || n < 1 {
+				return WorkloadSpec{}, fmt.Errorf("procs must be a positive integer, got %q", value)
+			}
+			spec.Processes = n
+			continue
+		}
+		dist, err := ParseDistribution(value)
+		if err != nil {
+			return WorkloadSpec{}, fmt.Errorf("%s: %w", name, err)
+		}
+		switch name {
+		case "bursts":
+			spec.Bursts = dist
+		case "cpu":
+			spec.CPU = dist
+		case "io":
+			spec.IO = dist
+		default:
+			return WorkloadSpec{}, fmt.Errorf("unknown workload spec field %q, expected procs, bursts, cpu or io", name)
+		}
+	}
+	return spec, nil
+}
+
+// splitSpecFields - splits by commas outside of parentheses
+func splitSpecFields(s string) []string {
+	fields := make([]string, 0)
+	depth, start := 0, 0
+	for i, r := range s {
+		switch r {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case ',':
+			if depth == 0 {
+				fields = append(fields, s[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(fields, s[start:])
+}
+
+func (s WorkloadSpec) String() string {
+	return fmt.Sprintf("procs=%d,bursts=%s,cpu=%s,io=%s", s.Processes, s.Bursts, s.CPU, s.IO)
+}
+
+// GenerateWorkload - random workload of spec, IO bursts pick one of devices uniformly
+func GenerateWorkload(r *rand.Rand, spec WorkloadSpec, devices int) Workload {
+	workload := make(Workload, spec.Processes)
+	for i := range workload {
+		bursts := spec.Bursts.Sample(r)
+		tasks := make([]m.Task, 0, 2*bursts)
+		for j := 0; j < bursts; j++ {
+			tasks = append(tasks,
+				m.Task{ResouceType: m.CPU, TotalTime: spec.CPU.Sample(r)},
+				m.Task{ResouceType: m.IO(1 + r.Intn(devices)), TotalTime: spec.IO.Sample(r)},
+			)
+		}
+		workload[i] = ProcessSpec{Tasks: tasks}
+	}
+	return workload
+}
+
+// String - workload in input file format
+func (w Workload) String() string {
+	var sb strings.Builder
+	for _, p := range w {
+		if p.Priority != 0 {
+			fmt.Fprintf(&sb, "%s(%d);", priorityField, p.Priority)
+		}
+		for _, t := range p.Tasks {
+			fmt.Fprintf(&sb, "%s(%d);", t.ResouceType, t.TotalTime)
+		}
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
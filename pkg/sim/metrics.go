@@ -0,0 +1,232 @@
+package sim
+
+import (
+	"math"
+	"slices"
+
+	m "github.com/Moleus/os-solver/pkg/machine"
+)
+
+// Metrics - aggregates over all processes and resources of a run
+type Metrics struct {
+	// Makespan - ticks until the last process finished
+	Makespan int
+	// Throughput - finished processes per tick
+	Throughput float64
+	// CPUUtilisation - busy share of all CPUs over makespan
+	CPUUtilisation float64
+	// CPUIdleTime - sum of ticks each CPU was free during makespan
+	CPUIdleTime int
+	// CPUSwitchTime - sum of ticks CPUs spent on context switches, neither busy nor idle
+	CPUSwitchTime int
+	// Resources - busy time of every CPU and IO device in timeline order
+	Resources []ResourceMetrics
+	// Queues - CPU queue and then IO queues
+	Queues               []QueueMetrics
+	Turnaround           Summary
+	Waiting              Summary
+	NormalizedTurnaround Summary
+	Response             Summary
+	// Fairness - Jain's index over Tr/Ts, 1 when all processes are delayed equally
+	Fairness float64
+	// Starved - ids of processes which waited in ready queue longer than the starvation threshold
+	Starved []int
+}
+
+type ResourceMetrics struct {
+	Name string
+	// BusyTime - ticks a process made progress on the resource, context switches are excluded
+	BusyTime    int
+	SwitchTime  int
+	IdleTime    int
+	Utilisation float64
+	m.SwitchCounters
+}
+
+// QueueMetrics - queue length over makespan and Little's law check L = λW
+type QueueMetrics struct {
+	Name string
+	// AvgLength - measured L, mean of per tick lengths
+	AvgLength float64
+	MaxLength int
+	// Arrivals - times a process entered the queue
+	Arrivals int
+	// ArrivalRate - λ, arrivals per tick
+	ArrivalRate float64
+	// AvgWait - W, mean ticks waited per arrival
+	AvgWait float64
+	// LittleLength - λW, equals AvgLength when waits are measured consistently with lengths
+	LittleLength float64
+}
+
+// Summary - distribution of a per-process value
+type Summary struct {
+	Mean   float64
+	Median float64
+	P95    float64
+}
+
+// computeMetrics - resources are in timeline column order
+func computeMetrics(header m.DumpState, timeline []m.DumpState, procs []m.ProcStats, tasks []m.TaskStats, resources []*m.Resource, starvationThreshold int) Metrics {
+	var metrics Metrics
+	for _, stats := range procs {
+		metrics.Makespan = max(metrics.Makespan, stats.ExitTime+1)
+	}
+	if metrics.Makespan > 0 {
+		metrics.Throughput = float64(len(procs)) / float64(metrics.Makespan)
+	}
+
+	names := append(append([]string{}, header.CpusState...), header.IoStates...)
+	metrics.Resources = make([]ResourceMetrics, len(names))
+	for i, name := range names {
+		metrics.Resources[i].Name = name
+		metrics.Resources[i].SwitchCounters = resources[i].Counters()
+		metrics.Resources[i].SwitchTime = resources[i].SwitchTime()
+	}
+	for tick, state := range timeline {
+		if tick >= metrics.Makespan {
+			break
+		}
+		for i, procId := range append(append([]string{}, state.CpusState...), state.IoStates...) {
+			if procId != "-" {
+				metrics.Resources[i].BusyTime++
+			}
+		}
+	}
+
+	cpuBusy := 0
+	for i := range metrics.Resources {
+		r := &metrics.Resources[i]
+		// occupied cells of the timeline include switch ticks
+		r.BusyTime -= r.SwitchTime
+		r.IdleTime = metrics.Makespan - r.BusyTime - r.SwitchTime
+		if metrics.Makespan > 0 {
+			r.Utilisation = float64(r.BusyTime) / float64(metrics.Makespan)
+		}
+		if i < len(header.CpusState) {
+			cpuBusy += r.BusyTime
+			metrics.CPUIdleTime += r.IdleTime
+			metrics.CPUSwitchTime += r.SwitchTime
+		}
+	}
+	if cpuTime := metrics.Makespan * len(header.CpusState); cpuTime > 0 {
+		metrics.CPUUtilisation = float64(cpuBusy) / float64(cpuTime)
+	}
+
+	metrics.Queues = queueMetrics(header, timeline, tasks, metrics.Makespan)
+
+	turnaround := make([]float64, len(procs))
+	waiting := make([]float64, len(procs))
+	normalized := make([]float64, len(procs))
+	response := make([]float64, len(procs))
+	for i, stats := range procs {
+		turnaround[i] = float64(stats.TurnaroundTime)
+		waiting[i] = float64(stats.ReadyOrBlockedTime)
+		normalized[i] = NormalizedTurnaround(stats)
+		response[i] = float64(stats.ResponseTime)
+	}
+	metrics.Turnaround = summarize(turnaround)
+	metrics.Waiting = summarize(waiting)
+	metrics.NormalizedTurnaround = summarize(normalized)
+	metrics.Response = summarize(response)
+	metrics.Fairness = jainIndex(normalized)
+
+	metrics.Starved = make([]int, 0)
+	for _, stats := range procs {
+		if starvationThreshold > 0 && stats.LongestReadyWait > starvationThreshold {
+			metrics.Starved = append(metrics.Starved, stats.ProcId)
+		}
+	}
+	return metrics
+}
+
+func queueMetrics(header m.DumpState, timeline []m.DumpState, tasks []m.TaskStats, makespan int) []QueueMetrics {
+	queues := make([]QueueMetrics, len(header.Queues))
+	for i, q := range header.Queues {
+		queues[i].Name = q.Name
+	}
+	totalLength := make([]int, len(queues))
+	for tick, state := range timeline {
+		if tick >= makespan {
+			break
+		}
+		for i, q := range state.Queues {
+			totalLength[i] += len(q.Procs)
+			queues[i].MaxLength = max(queues[i].MaxLength, len(q.Procs))
+		}
+	}
+
+	// queue 0 is the CPU queue, queue n is the queue of IO device n
+	totalWait := make([]int, len(queues))
+	for _, t := range tasks {
+		i := t.ResourceType.Device()
+		if i >= len(queues) {
+			continue
+		}
+		// preempted process enters CPU queue once more
+		queues[i].Arrivals += 1 + t.Preemptions
+		totalWait[i] += t.WaitTime
+	}
+
+	for i := range queues {
+		q := &queues[i]
+		if makespan > 0 {
+			q.AvgLength = float64(totalLength[i]) / float64(makespan)
+			q.ArrivalRate = float64(q.Arrivals) / float64(makespan)
+		}
+		if q.Arrivals > 0 {
+			q.AvgWait = float64(totalWait[i]) / float64(q.Arrivals)
+		}
+		q.LittleLength = q.ArrivalRate * q.AvgWait
+	}
+	return queues
+}
+
+// jainIndex - (Σx)² / (n Σx²), ranges from 1/n to 1
+func jainIndex(values []float64) float64 {
+	sum, sumSquares := 0.0, 0.0
+	for _, v := range values {
+		sum += v
+		sumSquares += v * v
+	}
+	if sumSquares == 0 {
+		return 0
+	}
+	return sum * sum / (float64(len(values)) * sumSquares)
+}
+
+func summarize(values []float64) Summary {
+	if len(values) == 0 {
+		return Summary{}
+	}
+	sorted := slices.Clone(values)
+	slices.Sort(sorted)
+	sum := 0.0
+	for _, v := range sorted {
+		sum += v
+	}
+	return Summary{
+		Mean:   sum / float64(len(sorted)),
+		Median: median(sorted),
+		P95:    percentile(sorted, 95),
+	}
+}
+
+func median(sorted []float64) float64 {
+	n := len(sorted)
+	if n%2 == 1 {
+		return sorted[n/2]
+	}
+	return (sorted[n/2-1] + sorted[n/2]) / 2
+}
+
+// percentile - nearest rank of sorted values
+func percentile(sorted []float64, p float64) float64 {
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	return sorted[max(rank, 1)-1]
+}
+
+// NormalizedTurnaround - Tr/Ts
+func NormalizedTurnaround(stats m.ProcStats) float64 {
+	return float64(stats.TurnaroundTime) / float64(stats.ServiceTime)
+}
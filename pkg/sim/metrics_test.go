@@ -0,0 +1,163 @@
+package sim
+
+import (
+	"context"
+	"math"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// metricsWorkload - P1 uses CPU 3 ticks, P2 2 ticks and P3 1 tick before their IO
+const metricsWorkload = "CPU(3);IO1(2);\nCPU(2);IO1(2);\nCPU(1);IO1(1);\n"
+
+func approxEqual(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
+
+// TestComputeMetrics - fcfs on 1 CPU and 1 device, processes arrive every tick:
+//
+//	tick  0 1 2 3 4 5 6 7
+//	CPU1  1 1 1 2 2 3 - -
+//	IO1   - - - 1 1 2 2 3
+//	CPUs  . 2 2 3 3 . . .
+//	          3
+//	IO1   . . . . . . 3 .
+func TestComputeMetrics(t *testing.T) {
+	metrics := runMetrics(t, 0)
+
+	if metrics.Makespan != 8 {
+		t.Errorf("makespan %d, want 8", metrics.Makespan)
+	}
+	resources := []struct {
+		name        string
+		busy, idle  int
+		utilisation float64
+	}{
+		{"CPU1", 6, 2, 0.75},
+		{"IO1", 5, 3, 0.625},
+	}
+	for i, want := range resources {
+		r := metrics.Resources[i]
+		if r.Name != want.name || r.BusyTime != want.busy || r.IdleTime != want.idle || !approxEqual(r.Utilisation, want.utilisation) {
+			t.Errorf("resource %d = %s busy %d idle %d utilisation %v, want %+v", i, r.Name, r.BusyTime, r.IdleTime, r.Utilisation, want)
+		}
+	}
+	if metrics.CPUIdleTime != 2 || !approxEqual(metrics.CPUUtilisation, 0.75) {
+		t.Errorf("CPU idle %d utilisation %v, want 2 and 0.75", metrics.CPUIdleTime, metrics.CPUUtilisation)
+	}
+
+	// CPU queue: lengths sum to 5, waits 0+2+3 over 3 arrivals. IO1 queue: P3 waits 1 tick
+	queues := []struct {
+		name      string
+		avgLength float64
+		avgWait   float64
+	}{
+		{"CPUs", 5.0 / 8, 5.0 / 3},
+		{"IO1", 1.0 / 8, 1.0 / 3},
+	}
+	for i, want := range queues {
+		q := metrics.Queues[i]
+		if q.Name != want.name || q.Arrivals != 3 || !approxEqual(q.AvgLength, want.avgLength) || !approxEqual(q.AvgWait, want.avgWait) {
+			t.Errorf("queue %d = %+v, want %+v with 3 arrivals", i, q, want)
+		}
+		if !approxEqual(q.LittleLength, q.AvgLength) {
+			t.Errorf("queue %s: λW %v != L %v", q.Name, q.LittleLength, q.AvgLength)
+		}
+	}
+
+	// Tr/Ts of the processes are 5/5, 6/4 and 6/2
+	if want := (Summary{Mean: 5.5 / 3, Median: 1.5, P95: 3}); !approxEqual(metrics.NormalizedTurnaround.Mean, want.Mean) ||
+		metrics.NormalizedTurnaround.Median != want.Median || metrics.NormalizedTurnaround.P95 != want.P95 {
+		t.Errorf("Tr/Ts %+v, want %+v", metrics.NormalizedTurnaround, want)
+	}
+	// (1 + 1.5 + 3)² / (3 * (1 + 2.25 + 9))
+	if want := 30.25 / 36.75; !approxEqual(metrics.Fairness, want) {
+		t.Errorf("fairness %v, want %v", metrics.Fairness, want)
+	}
+	// P2 waits 2 ticks in ready queue, P3 waits 3
+	if !slices.Equal(metrics.Starved, []int{2}) {
+		t.Errorf("starved %v, want [2]", metrics.Starved)
+	}
+}
+
+// TestComputeMetricsSwitchCost - same run with a 1 tick switch before every dispatch.
+// CPU1 is occupied on ticks 0-8, 3 of them are switches which count neither as busy nor idle
+func TestComputeMetricsSwitchCost(t *testing.T) {
+	metrics := runMetrics(t, 1)
+	if metrics.Makespan != 10 {
+		t.Errorf("makespan %d, want 10", metrics.Makespan)
+	}
+	cpu := metrics.Resources[0]
+	if cpu.BusyTime != 6 || cpu.SwitchTime != 3 || cpu.IdleTime != 1 || !approxEqual(cpu.Utilisation, 0.6) {
+		t.Errorf("CPU1 busy %d switch %d idle %d utilisation %v, want 6, 3, 1 and 0.6", cpu.BusyTime, cpu.SwitchTime, cpu.IdleTime, cpu.Utilisation)
+	}
+	if metrics.CPUSwitchTime != 3 || metrics.CPUIdleTime != 1 {
+		t.Errorf("CPU switch time %d idle %d, want 3 and 1", metrics.CPUSwitchTime, metrics.CPUIdleTime)
+	}
+	if io := metrics.Resources[1]; io.BusyTime != 5 || io.SwitchTime != 0 || io.IdleTime != 5 {
+		t.Errorf("IO1 busy %d switch %d idle %d, want 5, 0 and 5", io.BusyTime, io.SwitchTime, io.IdleTime)
+	}
+}
+
+// runMetrics - fcfs run of metricsWorkload on 1 CPU and 1 device, processes arrive every tick
+func runMetrics(t *testing.T, switchCost int) Metrics {
+	t.Helper()
+	workload, err := ParseWorkload(strings.NewReader(metricsWorkload))
+	if err != nil {
+		t.Fatal(err)
+	}
+	config := DefaultConfig()
+	config.CPUs = 1
+	config.Devices = 1
+	config.Arrival = NewFixedIntervalArrival(1)
+	config.StarvationThreshold = 2
+	config.SwitchCost = switchCost
+	res, err := Run(context.Background(), config, workload)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return res.Metrics
+}
+
+func TestPercentile(t *testing.T) {
+	sorted := make([]float64, 20)
+	for i := range sorted {
+		sorted[i] = float64(i + 1)
+	}
+	tests := []struct {
+		values []float64
+		p      float64
+		want   float64
+	}{
+		// nearest rank is ceil(p/100 * n)
+		{sorted, 95, 19},
+		{sorted, 96, 20},
+		{sorted, 50, 10},
+		{sorted, 0, 1},
+		{sorted[:3], 95, 3},
+		{sorted[:1], 95, 1},
+	}
+	for _, tt := range tests {
+		if got := percentile(tt.values, tt.p); got != tt.want {
+			t.Errorf("p%v of %d values = %v, want %v", tt.p, len(tt.values), got, tt.want)
+		}
+	}
+}
+
+func TestJainIndex(t *testing.T) {
+	tests := []struct {
+		values []float64
+		want   float64
+	}{
+		{[]float64{2, 2, 2, 2}, 1},
+		{[]float64{1, 0, 0, 0}, 0.25},
+		{[]float64{1, 3}, 0.8},
+		{[]float64{}, 0},
+	}
+	for _, tt := range tests {
+		if got := jainIndex(tt.values); !approxEqual(got, tt.want) {
+			t.Errorf("jainIndex(%v) = %v, want %v", tt.values, got, tt.want)
+		}
+	}
+}
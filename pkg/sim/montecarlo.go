@@ -0,0 +1,169 @@
+package sim
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/rand"
+	"runtime"
+	"sync"
+)
+
+// MonteCarloConfig - evaluation of algorithms on random workloads
+type MonteCarloConfig struct {
+	// Runs - number of generated workloads, at least 2 to estimate confidence intervals
+	Runs int
+	// Seed - workload i is generated from Seed+i, so results don't depend on parallelism
+	Seed       int64
+	Spec       WorkloadSpec
+	Algorithms []string
+}
+
+// Estimate - mean of a metric over runs with 95% confidence interval of the mean
+type Estimate struct {
+	Mean   float64
+	StdDev float64
+	Low    float64
+	High   float64
+}
+
+type AlgorithmEstimate struct {
+	Algorithm string
+	// Metrics - in order of SweepMetrics
+	Metrics []Estimate
+}
+
+type MonteCarloResult struct {
+	// Estimates - in order of MonteCarloConfig.Algorithms
+	Estimates []AlgorithmEstimate
+	// WinRates - WinRates[metric][a][b] is the share of workloads where algorithm a did better than b, ties count as half
+	WinRates [][][]float64
+}
+
+// MonteCarlo - runs every algorithm on the same random workloads with other settings taken from base.
+// Runs are executed in parallel, so base Observer and LogOutput are not used
+func MonteCarlo(ctx context.Context, base Config, mc MonteCarloConfig) (MonteCarloResult, error) {
+	if mc.Runs < 2 {
+		return MonteCarloResult{}, fmt.Errorf("at least 2 runs are required, got %d", mc.Runs)
+	}
+	if len(mc.Algorithms) == 0 {
+		return MonteCarloResult{}, fmt.Errorf("no algorithms to compare")
+	}
+	base.Observer = nil
+	base.LogOutput = nil
+
+	workloads := make([]Workload, mc.Runs)
+	for i := range workloads {
+		r := rand.New(rand.NewSource(mc.Seed + int64(i)))
+		workloads[i] = GenerateWorkload(r, mc.Spec, base.Devices)
+	}
+
+	// metrics[algo][run]
+	metrics := make([][]Metrics, len(mc.Algorithms))
+	for a := range metrics {
+		metrics[a] = make([]Metrics, mc.Runs)
+	}
+	err := runParallel(len(mc.Algorithms)*mc.Runs, func(job int) error {
+		a, run := job/mc.Runs, job%mc.Runs
+		config := base
+		config.Algorithm = mc.Algorithms[a]
+		res, err := Run(ctx, config, workloads[run])
+		if err != nil {
+			return fmt.Errorf("%s on workload %d (seed %d): %w", config.Algorithm, run+1, mc.Seed+int64(run), err)
+		}
+		metrics[a][run] = res.Metrics
+		return nil
+	})
+	if err != nil {
+		return MonteCarloResult{}, err
+	}
+
+	var result MonteCarloResult
+	for a, name := range mc.Algorithms {
+		estimate := AlgorithmEstimate{Algorithm: name, Metrics: make([]Estimate, len(SweepMetrics))}
+		for i, metric := range SweepMetrics {
+			values := make([]float64, mc.Runs)
+			for run, mt := range metrics[a] {
+				values[run] = metric.Value(mt)
+			}
+			estimate.Metrics[i] = estimateMean(values)
+		}
+		result.Estimates = append(result.Estimates, estimate)
+	}
+
+	result.WinRates = make([][][]float64, len(SweepMetrics))
+	for i, metric := range SweepMetrics {
+		result.WinRates[i] = make([][]float64, len(mc.Algorithms))
+		for a := range mc.Algorithms {
+			result.WinRates[i][a] = make([]float64, len(mc.Algorithms))
+			for b := range mc.Algorithms {
+				if a == b {
+					continue
+				}
+				wins := 0.0
+				for run := 0; run < mc.Runs; run++ {
+					va, vb := metric.Value(metrics[a][run]), metric.Value(metrics[b][run])
+					switch {
+					case va == vb:
+						wins += 0.5
+					case (va < vb) == metric.LowerIsBetter:
+						wins++
+					}
+				}
+				result.WinRates[i][a][b] = wins / float64(mc.Runs)
+			}
+		}
+	}
+	return result, nil
+}
+
+// tCritical95 - two-sided 95% quantiles of Student's t for 1..30 degrees of freedom
+var tCritical95 = []float64{
+	12.706, 4.303, 3.182, 2.776, 2.571, 2.447, 2.365, 2.306, 2.262, 2.228,
+	2.201, 2.179, 2.160, 2.145, 2.131, 2.120, 2.110, 2.101, 2.093, 2.086,
+	2.080, 2.074, 2.069, 2.064, 2.060, 2.056, 2.052, 2.048, 2.045, 2.042,
+}
+
+// estimateMean - sample mean with t-interval, normal approximation after 30 degrees of freedom
+func estimateMean(values []float64) Estimate {
+	n := float64(len(values))
+	sum := 0.0
+	for _, v := range values {
+		sum += v
+	}
+	mean := sum / n
+	squares := 0.0
+	for _, v := range values {
+		squares += (v - mean) * (v - mean)
+	}
+	stdDev := math.Sqrt(squares / (n - 1))
+	t := 1.96
+	if df := len(values) - 1; df <= len(tCritical95) {
+		t = tCritical95[df-1]
+	}
+	halfWidth := t * stdDev / math.Sqrt(n)
+	return Estimate{Mean: mean, StdDev: stdDev, Low: mean - halfWidth, High: mean + halfWidth}
+}
+
+// runParallel - calls job for 0..n-1 on up to GOMAXPROCS goroutines, returns error of the lowest failed job
+func runParallel(n int, job func(i int) error) error {
+	errs := make([]error, n)
+	sem := make(chan struct{}, runtime.GOMAXPROCS(0))
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			errs[i] = job(i)
+		}(i)
+	}
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,125 @@
+package sim
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	m "github.com/Moleus/os-solver/pkg/machine"
+)
+
+type ParamKind int
+
+const (
+	IntParam ParamKind = iota
+	StringParam
+)
+
+func (k ParamKind) String() string {
+	switch k {
+	case IntParam:
+		return "int"
+	case StringParam:
+		return "string"
+	}
+	return "unknown"
+}
+
+// ParamSpec - describes one parameter accepted by an algorithm
+type ParamSpec struct {
+	Name        string
+	Kind        ParamKind
+	Default     string
+	Description string
+}
+
+// SchedulerEnv - what a factory gets to build CPU scheduling policy
+type SchedulerEnv struct {
+	// Queue - CPU ready queue
+	Queue *m.ProcQueue
+	CPUs  int
+	// Params - algorithm params with defaults filled in and kinds checked
+	Params Params
+}
+
+// SchedulerFactory - builds evictor and selection function for a single run
+type SchedulerFactory func(env SchedulerEnv) (m.Evictor, m.SelectionFunction, error)
+
+type Algorithm struct {
+	Name        string
+	Description string
+	Params      []ParamSpec
+	New         SchedulerFactory
+}
+
+var (
+	registryMu sync.RWMutex
+	registry   = make(map[string]Algorithm)
+)
+
+// Register - makes algorithm available by name for Config.Algorithm.
+// Panics if the name is taken, like database/sql drivers do.
+func Register(algo Algorithm) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	if algo.Name == "" || algo.New == nil {
+		panic("sim: Register algorithm without name or factory")
+	}
+	if _, dup := registry[algo.Name]; dup {
+		panic("sim: Register called twice for algorithm " + algo.Name)
+	}
+	registry[algo.Name] = algo
+}
+
+func LookupAlgorithm(name string) (Algorithm, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	algo, ok := registry[name]
+	return algo, ok
+}
+
+// Algorithms - all registered algorithms sorted by name
+func Algorithms() []Algorithm {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	algos := make([]Algorithm, 0, len(registry))
+	for _, algo := range registry {
+		algos = append(algos, algo)
+	}
+	slices.SortFunc(algos, func(a, b Algorithm) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	return algos
+}
+
+// resolveParams - fills defaults and checks kinds of params known to algo. Unknown params are ignored
+func (algo Algorithm) resolveParams(params Params) (Params, error) {
+	resolved := make(Params, len(algo.Params))
+	for _, spec := range algo.Params {
+		value, ok := params[spec.Name]
+		if !ok {
+			value = spec.Default
+		}
+		if spec.Kind == IntParam {
+			if _, err := strconv.Atoi(value); err != nil {
+				return nil, fmt.Errorf("param %s of %s must be int, got %q", spec.Name, algo.Name, value)
+			}
+		}
+		resolved[spec.Name] = value
+	}
+	return resolved, nil
+}
+
+func newScheduler(name string, params Params, procQueue *m.ProcQueue, cpuCount int) (m.Evictor, m.SelectionFunction, error) {
+	algo, ok := LookupAlgorithm(name)
+	if !ok {
+		return nil, nil, fmt.Errorf("unknown scheduling algorithm %s", name)
+	}
+	resolved, err := algo.resolveParams(params)
+	if err != nil {
+		return nil, nil, err
+	}
+	return algo.New(SchedulerEnv{Queue: procQueue, CPUs: cpuCount, Params: resolved})
+}
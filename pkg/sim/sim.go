@@ -0,0 +1,133 @@
+// Package sim wires processes, queues and schedulers into a machine and runs it.
+// Every Run owns all of its state, so simulations can run in parallel.
+package sim
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+
+	log "github.com/Moleus/os-solver/pkg/logging"
+	m "github.com/Moleus/os-solver/pkg/machine"
+)
+
+type Result struct {
+	// Config - configuration the run was made with
+	Config Config
+	// Header - column names of Timeline rows
+	Header m.DumpState
+	// Timeline - occupancy of every resource, one row per tick
+	Timeline []m.DumpState
+	// Procs - per-process statistics, indexed by process id
+	Procs []m.ProcStats
+	// Tasks - per-burst statistics ordered by process id and burst index
+	Tasks []m.TaskStats
+	// Events - every event of the run in order of occurrence
+	Events  []m.Event
+	Metrics Metrics
+}
+
+// Title - algorithm and CPU count, heading of charts and reports of the run
+func (r Result) Title() string {
+	return fmt.Sprintf("%s, %d CPUs", r.Config.Algorithm, r.Config.CPUs)
+}
+
+// Run - simulates workload on a machine described by config.
+// Returns ctx error if ctx is done before all processes finish.
+func Run(ctx context.Context, config Config, workload Workload) (res Result, err error) {
+	if err := config.Validate(); err != nil {
+		return Result{}, fmt.Errorf("invalid config: %w", err)
+	}
+	if err := workload.Validate(config.Devices); err != nil {
+		return Result{}, fmt.Errorf("invalid workload: %w", err)
+	}
+
+	clock := &m.Clock{CurrentTick: 0}
+
+	logOutput := config.LogOutput
+	if logOutput == nil {
+		logOutput = io.Discard
+	}
+	defaultHandler := slog.NewTextHandler(logOutput, &slog.HandlerOptions{Level: config.LogLevel})
+	logger := slog.New(log.NewTickLoggerHandler(logOutput, defaultHandler, clock))
+
+	processes := make([]*m.Process, len(workload))
+	for id, spec := range workload {
+		// machine advances tasks in place, keep caller's workload untouched
+		tasks := make([]m.Task, len(spec.Tasks))
+		copy(tasks, spec.Tasks)
+		processes[id] = m.NewProcess(id, config.Arrival.ArrivalTime(id), spec.Priority, tasks, logger, clock)
+	}
+
+	logger.Info(fmt.Sprintf("Running with %d CPUs", config.CPUs))
+	logger.Info(fmt.Sprintf("Tie break of same tick CPU queue entrants: %s", config.TieBreak))
+	logger.Info(fmt.Sprintf("Total processes: %d", len(processes)))
+
+	cpuProcQueue := m.NewProcQueue("CPUs", clock)
+	evictor, selectionFunc, err := newScheduler(config.Algorithm, config.Params, cpuProcQueue, config.CPUs)
+	if err != nil {
+		return Result{}, err
+	}
+	timeline := make([]m.DumpState, 0)
+	events := make([]m.Event, 0)
+	observer := m.MultiObserver{
+		m.TickFunc(func(state m.DumpState) {
+			timeline = append(timeline, state)
+		}),
+		m.EventFunc(func(e m.Event) {
+			events = append(events, e)
+		}),
+	}
+	if config.Observer != nil {
+		observer = append(observer, config.Observer)
+	}
+
+	cpuScheduler := m.NewSchedulerWrapper("CPUs", cpuProcQueue, selectionFunc, evictor, m.NewCpuPool(config.CPUs, config.SwitchCost), clock, logger, observer)
+
+	// IO is always fcfs
+	ioSchedulers := make([]m.Scheduler, config.Devices)
+	for i := range ioSchedulers {
+		name := m.IO(i + 1).String()
+		ioQueue := m.NewProcQueue(name, clock)
+		ioSchedulers[i] = m.NewSchedulerWrapper(name, ioQueue, m.NewSelectionFIFO(), m.NewNonPreemptive(), m.NewResource(name, m.IO(i+1)), clock, logger, observer)
+	}
+
+	machine := m.NewMachine(cpuScheduler, ioSchedulers, clock, logger, observer, config.CPUs, config.TieBreak)
+
+	// machine reports broken invariants with panics
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("simulation failed at tick %d: %v", clock.GetCurrentTick(), r)
+		}
+	}()
+	if err := machine.RunContext(ctx, processes); err != nil {
+		return Result{}, err
+	}
+
+	procs := make([]m.ProcStats, len(processes))
+	tasks := make([]m.TaskStats, 0)
+	for i, p := range processes {
+		procs[i] = p.GetStats()
+		tasks = append(tasks, p.GetTaskStats()...)
+	}
+
+	resources := append([]*m.Resource{}, cpuScheduler.GetResource().Resources()...)
+	for _, s := range ioSchedulers {
+		resources = append(resources, s.GetResource().Resources()...)
+	}
+	header := machine.DumpHeader()
+	metrics := computeMetrics(header, timeline, procs, tasks, resources, config.StarvationThreshold)
+	for _, id := range metrics.Starved {
+		logger.Warn(fmt.Sprintf("Process %d starved: waited %d ticks in ready queue, threshold is %d", id, procs[id].LongestReadyWait, config.StarvationThreshold))
+	}
+	return Result{
+		Config:   config,
+		Header:   header,
+		Timeline: timeline,
+		Procs:    procs,
+		Tasks:    tasks,
+		Events:   events,
+		Metrics:  metrics,
+	}, nil
+}
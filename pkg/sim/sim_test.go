@@ -0,0 +1,54 @@
+package sim
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+)
+
+const testWorkload = "CPU(4);IO1(6);CPU(3);IO2(2);\nCPU(7);IO2(3);CPU(1);IO1(2);\nCPU(2);IO1(4);CPU(5);IO1(1);\nCPU(6);IO2(5);CPU(2);IO2(3);\nCPU(1);IO1(2);CPU(4);IO2(4);\n"
+
+func TestRunConcurrent(t *testing.T) {
+	workload, err := ParseWorkload(strings.NewReader(testWorkload))
+	if err != nil {
+		t.Fatal(err)
+	}
+	config := DefaultConfig()
+	config.CPUs = 2
+
+	algorithms := []string{"fcfs", "rr1", "rr4", "spn", "srt", "hrrn"}
+	want := make([]Result, len(algorithms))
+	for i, algo := range algorithms {
+		config.Algorithm = algo
+		if want[i], err = Run(context.Background(), config, workload); err != nil {
+			t.Fatalf("%s: %v", algo, err)
+		}
+	}
+
+	const copies = 8
+	got := make([]Result, copies*len(algorithms))
+	errs := make([]error, len(got))
+	var wg sync.WaitGroup
+	for i := range got {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			c := config
+			c.Algorithm = algorithms[i%len(algorithms)]
+			got[i], errs[i] = Run(context.Background(), c, workload)
+		}(i)
+	}
+	wg.Wait()
+
+	for i, res := range got {
+		name := algorithms[i%len(algorithms)]
+		if errs[i] != nil {
+			t.Fatalf("%s: %v", name, errs[i])
+		}
+		if !reflect.DeepEqual(res, want[i%len(algorithms)]) {
+			t.Errorf("%s: concurrent run differs from sequential one", name)
+		}
+	}
+}
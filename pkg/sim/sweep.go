@@ -0,0 +1,195 @@
+package sim
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// SweepParam - integer setting varied by Sweep.
+// Name is one of cpus, interval, switch-cost or an int param of the algorithm, e.g. quantum
+type SweepParam struct {
+	Name   string
+	Values []int
+}
+
+// ParseSweepParam - parses name=from..to, name=from..to/step or name=v1,v2,...
+func ParseSweepParam(s string) (SweepParam, error) {
+	name, spec, ok := strings.Cut(s, "=")
+	if !ok || name == "" || spec == "" {
+		return SweepParam{}, fmt.Errorf("expected name=values, got %q", s)
+	}
+	values, err := parseSweepValues(spec)
+	if err != nil {
+		return SweepParam{}, fmt.Errorf("sweep %s: %w", name, err)
+	}
+	return SweepParam{Name: name, Values: values}, nil
+}
+
+func parseSweepValues(spec string) ([]int, error) {
+	if from, to, ok := strings.Cut(spec, ".."); ok {
+		to, stepStr, hasStep := strings.Cut(to, "/")
+		step := 1
+		if hasStep {
+			var err error
+			if step, err = strconv.Atoi(stepStr); err != nil || step < 1 {
+				return nil, fmt.Errorf("step must be a positive integer, got %q", stepStr)
+			}
+		}
+		return rangeValues(from, to, step)
+	}
+	values := make([]int, 0)
+	for _, field := range strings.Split(spec, ",") {
+		v, err := strconv.Atoi(strings.TrimSpace(field))
+		if err != nil {
+			return nil, fmt.Errorf("bad value %q", field)
+		}
+		values = append(values, v)
+	}
+	return values, nil
+}
+
+func rangeValues(fromStr string, toStr string, step int) ([]int, error) {
+	from, err := strconv.Atoi(fromStr)
+	if err != nil {
+		return nil, fmt.Errorf("bad range start %q", fromStr)
+	}
+	to, err := strconv.Atoi(toStr)
+	if err != nil {
+		return nil, fmt.Errorf("bad range end %q", toStr)
+	}
+	if to < from {
+		return nil, fmt.Errorf("empty range %d..%d", from, to)
+	}
+	values := make([]int, 0)
+	for v := from; v <= to; v += step {
+		values = append(values, v)
+	}
+	return values, nil
+}
+
+// SweepPoint - metrics of one combination, Values are in order of swept params
+type SweepPoint struct {
+	Values  []int
+	Metrics Metrics
+}
+
+// SweepMetric - aggregate metric compared across sweep points
+type SweepMetric struct {
+	Name          string
+	LowerIsBetter bool
+	Value         func(metrics Metrics) float64
+}
+
+// SweepMetrics - columns of sweep grid
+var SweepMetrics = []SweepMetric{
+	{"Makespan", true, func(m Metrics) float64 { return float64(m.Makespan) }},
+	{"Throughput", false, func(m Metrics) float64 { return m.Throughput }},
+	{"CPU utilisation", false, func(m Metrics) float64 { return m.CPUUtilisation }},
+	{"CPU switch time", true, func(m Metrics) float64 { return float64(m.CPUSwitchTime) }},
+	{"Turnaround mean", true, func(m Metrics) float64 { return m.Turnaround.Mean }},
+	{"Waiting mean", true, func(m Metrics) float64 { return m.Waiting.Mean }},
+	{"Tr/Ts mean", true, func(m Metrics) float64 { return m.NormalizedTurnaround.Mean }},
+	{"Response mean", true, func(m Metrics) float64 { return m.Response.Mean }},
+	{"Jain fairness", false, func(m Metrics) float64 { return m.Fairness }},
+	{"Starved processes", true, func(m Metrics) float64 { return float64(len(m.Starved)) }},
+}
+
+// Sweep - runs workload with every combination of params values applied to base, the last param varies fastest.
+// Runs are executed in parallel, so base Observer and LogOutput are not used
+func Sweep(ctx context.Context, base Config, workload Workload, params []SweepParam) ([]SweepPoint, error) {
+	if len(params) == 0 {
+		return nil, fmt.Errorf("nothing to sweep")
+	}
+	base.Observer = nil
+	base.LogOutput = nil
+
+	combinations := [][]int{{}}
+	for _, param := range params {
+		if len(param.Values) == 0 {
+			return nil, fmt.Errorf("sweep %s has no values", param.Name)
+		}
+		next := make([][]int, 0, len(combinations)*len(param.Values))
+		for _, prefix := range combinations {
+			for _, v := range param.Values {
+				next = append(next, append(slices.Clone(prefix), v))
+			}
+		}
+		combinations = next
+	}
+
+	configs := make([]Config, len(combinations))
+	for i, values := range combinations {
+		config := base
+		config.Params = Params{}
+		for name, value := range base.Params {
+			config.Params[name] = value
+		}
+		for j, param := range params {
+			if err := applySweepParam(&config, param.Name, values[j]); err != nil {
+				return nil, err
+			}
+		}
+		configs[i] = config
+	}
+
+	points := make([]SweepPoint, len(combinations))
+	err := runParallel(len(configs), func(i int) error {
+		res, err := Run(ctx, configs[i], workload)
+		if err != nil {
+			return fmt.Errorf("sweep point %s: %w", formatSweepPoint(params, combinations[i]), err)
+		}
+		points[i] = SweepPoint{Values: combinations[i], Metrics: res.Metrics}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return points, nil
+}
+
+func applySweepParam(config *Config, name string, value int) error {
+	switch name {
+	case "cpus":
+		config.CPUs = value
+	case "interval":
+		config.Arrival = NewFixedIntervalArrival(value)
+	case "switch-cost":
+		config.SwitchCost = value
+	default:
+		algo, ok := LookupAlgorithm(config.Algorithm)
+		if !ok {
+			return fmt.Errorf("unknown algorithm %q", config.Algorithm)
+		}
+		idx := slices.IndexFunc(algo.Params, func(p ParamSpec) bool { return p.Name == name })
+		if idx < 0 || algo.Params[idx].Kind != IntParam {
+			return fmt.Errorf("can't sweep %s: not cpus, interval, switch-cost or int param of %s", name, algo.Name)
+		}
+		config.Params[name] = strconv.Itoa(value)
+	}
+	return nil
+}
+
+func formatSweepPoint(params []SweepParam, values []int) string {
+	pairs := make([]string, len(params))
+	for i, param := range params {
+		pairs[i] = fmt.Sprintf("%s=%d", param.Name, values[i])
+	}
+	return strings.Join(pairs, ",")
+}
+
+// BestSweepPoints - index of the best point for every metric of SweepMetrics, the first one wins ties
+func BestSweepPoints(points []SweepPoint) []int {
+	best := make([]int, len(SweepMetrics))
+	for i, metric := range SweepMetrics {
+		for j, point := range points {
+			v, bestV := metric.Value(point.Metrics), metric.Value(points[best[i]].Metrics)
+			if (metric.LowerIsBetter && v < bestV) || (!metric.LowerIsBetter && v > bestV) {
+				best[i] = j
+			}
+		}
+	}
+	return best
+}
@@ -0,0 +1,143 @@
+package sim
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	m "github.com/Moleus/os-solver/pkg/machine"
+)
+
+// ProcessSpec - sequence of CPU and IO bursts of a single process
+type ProcessSpec struct {
+	Tasks []m.Task
+	// Priority - visible to policies as priority, 0 if not set
+	Priority int
+}
+
+// priorityField - name of the process field with priority, e.g. PRIORITY(2);CPU(4);IO1(6);
+const priorityField = "PRIORITY"
+
+// Workload - processes in order of arrival. Process id is its index
+type Workload []ProcessSpec
+
+// ParseTask - parses one burst like CPU(6) or IO2(16)
+func ParseTask(task string) (m.Task, error) {
+	task = strings.TrimSpace(task)
+	open := strings.Index(task, "(")
+	if open == -1 || !strings.HasSuffix(task, ")") {
+		return m.Task{}, fmt.Errorf("malformed task %q, expected NAME(time)", task)
+	}
+
+	var taskType m.ResourceType
+	taskTypeStr := task[:open]
+	switch {
+	case taskTypeStr == "CPU":
+		taskType = m.CPU
+	case strings.HasPrefix(taskTypeStr, "IO"):
+		device, err := strconv.Atoi(taskTypeStr[2:])
+		if err != nil || device < 1 {
+			return m.Task{}, fmt.Errorf("unknown IO device in task %q", task)
+		}
+		taskType = m.IO(device)
+	default:
+		return m.Task{}, fmt.Errorf("unknown resource in task %q", task)
+	}
+
+	taskTime, err := strconv.Atoi(task[open+1 : len(task)-1])
+	if err != nil {
+		return m.Task{}, fmt.Errorf("bad time in task %q: %w", task, err)
+	}
+	if taskTime < 1 {
+		return m.Task{}, fmt.Errorf("task %q must take at least one tick", task)
+	}
+
+	return m.Task{ResouceType: taskType, TotalTime: taskTime}, nil
+}
+
+// ParsePriority - parses PRIORITY(n), n may be negative
+func ParsePriority(field string) (int, error) {
+	field = strings.TrimSpace(field)
+	if !strings.HasPrefix(field, priorityField+"(") || !strings.HasSuffix(field, ")") {
+		return 0, fmt.Errorf("malformed priority %q, expected %s(n)", field, priorityField)
+	}
+	priority, err := strconv.Atoi(field[len(priorityField)+1 : len(field)-1])
+	if err != nil {
+		return 0, fmt.Errorf("bad priority %q: %w", field, err)
+	}
+	return priority, nil
+}
+
+// ParseProcess - parses semicolon separated tasks of one process. An optional PRIORITY(n) field sets its priority
+func ParseProcess(line string) (ProcessSpec, error) {
+	line = strings.TrimSpace(line)
+	fields := strings.Split(line, ";")
+	if fields[len(fields)-1] == "" {
+		fields = fields[:len(fields)-1]
+	}
+
+	var spec ProcessSpec
+	hasPriority := false
+	spec.Tasks = make([]m.Task, 0, len(fields))
+	for _, field := range fields {
+		if strings.HasPrefix(strings.TrimSpace(field), priorityField) {
+			if hasPriority {
+				return ProcessSpec{}, fmt.Errorf("priority is set twice")
+			}
+			priority, err := ParsePriority(field)
+			if err != nil {
+				return ProcessSpec{}, err
+			}
+			spec.Priority, hasPriority = priority, true
+			continue
+		}
+		t, err := ParseTask(field)
+		if err != nil {
+			return ProcessSpec{}, err
+		}
+		spec.Tasks = append(spec.Tasks, t)
+	}
+	return spec, nil
+}
+
+// ParseWorkload - reads one process per line. Blank lines are skipped
+func ParseWorkload(r io.Reader) (Workload, error) {
+	scanner := bufio.NewScanner(r)
+	var workload = make(Workload, 0)
+	var lineNo int
+	for scanner.Scan() {
+		lineNo++
+		if strings.TrimSpace(scanner.Text()) == "" {
+			continue
+		}
+		process, err := ParseProcess(scanner.Text())
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNo, err)
+		}
+		workload = append(workload, process)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return workload, nil
+}
+
+// Validate - checks that every process starts on CPU and uses only existing IO devices
+func (w Workload) Validate(devices int) error {
+	for id, p := range w {
+		if len(p.Tasks) == 0 {
+			return fmt.Errorf("process %d has no tasks", id+1)
+		}
+		if p.Tasks[0].ResouceType != m.CPU {
+			return fmt.Errorf("process %d must start with a CPU task", id+1)
+		}
+		for _, t := range p.Tasks {
+			if t.ResouceType.Device() > devices {
+				return fmt.Errorf("process %d uses %s, but only %d IO devices are configured", id+1, t.ResouceType, devices)
+			}
+		}
+	}
+	return nil
+}
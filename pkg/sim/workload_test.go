@@ -0,0 +1,36 @@
+package sim
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseWorkloadPriority(t *testing.T) {
+	input := "PRIORITY(2);CPU(4);IO1(6);\nCPU(3);PRIORITY(-1);\nCPU(1);\n"
+	workload, err := ParseWorkload(strings.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+	priorities := []int{workload[0].Priority, workload[1].Priority, workload[2].Priority}
+	if !reflect.DeepEqual(priorities, []int{2, -1, 0}) {
+		t.Errorf("priorities %v, want [2 -1 0]", priorities)
+	}
+	if len(workload[0].Tasks) != 2 || len(workload[1].Tasks) != 1 {
+		t.Errorf("priority field must not be parsed as a task: %v", workload)
+	}
+
+	again, err := ParseWorkload(strings.NewReader(workload.String()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(again, workload) {
+		t.Errorf("String does not round trip: %q", workload.String())
+	}
+
+	for _, bad := range []string{"PRIORITY(x);CPU(1);", "PRIORITY(1);PRIORITY(2);CPU(1);", "PRIORITY 1;CPU(1);"} {
+		if _, err := ParseProcess(bad); err == nil {
+			t.Errorf("%q: expected error", bad)
+		}
+	}
+}
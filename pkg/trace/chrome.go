@@ -0,0 +1,108 @@
+// Package trace exports simulation runs for external tools
+package trace
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/Moleus/os-solver/pkg/gantt"
+	m "github.com/Moleus/os-solver/pkg/machine"
+	"github.com/Moleus/os-solver/pkg/sim"
+)
+
+// tickMicros - trace timestamps are in microseconds, a tick is shown as a millisecond
+const tickMicros = 1000
+
+const machinePid = 1
+
+// chromeEvent - entry of Chrome Trace Event Format
+type chromeEvent struct {
+	Name string         `json:"name"`
+	Cat  string         `json:"cat,omitempty"`
+	Ph   string         `json:"ph"`
+	Ts   int            `json:"ts"`
+	Dur  int            `json:"dur,omitempty"`
+	Pid  int            `json:"pid"`
+	Tid  int            `json:"tid"`
+	S    string         `json:"s,omitempty"`
+	Args map[string]any `json:"args,omitempty"`
+}
+
+type chromeTrace struct {
+	TraceEvents     []chromeEvent `json:"traceEvents"`
+	DisplayTimeUnit string        `json:"displayTimeUnit"`
+}
+
+// WriteChromeTrace - trace with a track per CPU and IO device holding burst slices, instant events for
+// arrivals, preemptions and finishes and a counter track per queue. Opens in Perfetto and chrome://tracing
+func WriteChromeTrace(w io.Writer, res sim.Result) error {
+	chart := gantt.ByResource(res.Header, res.Timeline)
+	tids := make(map[string]int, len(chart.Rows))
+
+	events := []chromeEvent{{
+		Name: "process_name", Ph: "M", Pid: machinePid,
+		Args: map[string]any{"name": res.Title()},
+	}}
+	for i, r := range chart.Rows {
+		tids[r.Name] = i + 1
+		events = append(events,
+			chromeEvent{Name: "thread_name", Ph: "M", Pid: machinePid, Tid: i + 1, Args: map[string]any{"name": r.Name}},
+			chromeEvent{Name: "thread_sort_index", Ph: "M", Pid: machinePid, Tid: i + 1, Args: map[string]any{"sort_index": i}},
+		)
+	}
+
+	for _, r := range chart.Rows {
+		for _, seg := range r.Segments() {
+			id, _ := strconv.Atoi(seg.Label)
+			args := map[string]any{"process": id, "start tick": seg.Start, "ticks": seg.End - seg.Start}
+			if burst, ok := gantt.BurstAt(res.Tasks, id, r.Name, seg.Start); ok {
+				args["burst"] = burst + 1
+			}
+			events = append(events, chromeEvent{
+				Name: "P" + seg.Label, Cat: "burst", Ph: "X",
+				Ts: seg.Start * tickMicros, Dur: (seg.End - seg.Start) * tickMicros,
+				Pid: machinePid, Tid: tids[r.Name], Args: args,
+			})
+		}
+	}
+
+	for _, e := range res.Events {
+		instant := chromeEvent{Cat: e.Type.String(), Ph: "i", Ts: e.Tick * tickMicros, Pid: machinePid,
+			Args: map[string]any{"process": e.ProcId + 1, "burst": e.TaskIndex + 1}}
+		switch e.Type {
+		case m.EventPreempt:
+			instant.Name = fmt.Sprintf("P%d preempted", e.ProcId+1)
+			instant.Tid = tids[e.Resource]
+			instant.S = "t"
+		case m.EventArrival:
+			instant.Name = fmt.Sprintf("P%d arrived", e.ProcId+1)
+			instant.S = "p"
+		case m.EventTerminate:
+			instant.Name = fmt.Sprintf("P%d finished", e.ProcId+1)
+			instant.S = "p"
+		default:
+			continue
+		}
+		events = append(events, instant)
+	}
+
+	for qi, q := range res.Header.Queues {
+		last := -1
+		for tick, state := range res.Timeline {
+			length := len(state.Queues[qi].Procs)
+			if length == last {
+				continue
+			}
+			last = length
+			events = append(events, chromeEvent{
+				Name: q.Name + " queue", Ph: "C", Ts: tick * tickMicros, Pid: machinePid,
+				Args: map[string]any{"length": length},
+			})
+		}
+	}
+
+	enc := json.NewEncoder(w)
+	return enc.Encode(chromeTrace{TraceEvents: events, DisplayTimeUnit: "ms"})
+}
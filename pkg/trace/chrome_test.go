@@ -0,0 +1,89 @@
+package trace
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/Moleus/os-solver/pkg/sim"
+)
+
+// runTiny - fcfs on 1 CPU and 1 device, processes arrive every tick:
+//
+//	tick  0 1 2 3 4 5 6 7
+//	CPU1  1 1 1 2 2 3 - -
+//	IO1   - - - 1 1 2 2 3
+func runTiny(t *testing.T) sim.Result {
+	t.Helper()
+	workload, err := sim.ParseWorkload(strings.NewReader("CPU(3);IO1(2);\nCPU(2);IO1(2);\nCPU(1);IO1(1);\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	config := sim.DefaultConfig()
+	config.CPUs = 1
+	config.Devices = 1
+	config.Arrival = sim.NewFixedIntervalArrival(1)
+	res, err := sim.Run(context.Background(), config, workload)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return res
+}
+
+func TestWriteChromeTrace(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteChromeTrace(&buf, runTiny(t)); err != nil {
+		t.Fatal(err)
+	}
+	var trace chromeTrace
+	if err := json.Unmarshal(buf.Bytes(), &trace); err != nil {
+		t.Fatal(err)
+	}
+
+	tracks := make(map[int]string)
+	bursts := make(map[string][]string)
+	counters := make(map[string][]int)
+	instants := 0
+	for _, e := range trace.TraceEvents {
+		switch e.Ph {
+		case "M":
+			if e.Name == "process_name" && e.Args["name"] != "fcfs, 1 CPUs" {
+				t.Errorf("process name %v", e.Args["name"])
+			}
+			if e.Name == "thread_name" {
+				tracks[e.Tid] = e.Args["name"].(string)
+			}
+		case "X":
+			bursts[tracks[e.Tid]] = append(bursts[tracks[e.Tid]], e.Name)
+		case "C":
+			// json numbers are decoded as float64
+			counters[e.Name] = append(counters[e.Name], int(e.Args["length"].(float64)))
+		case "i":
+			instants++
+		}
+	}
+
+	wantBursts := map[string][]string{"CPU1": {"P1", "P2", "P3"}, "IO1": {"P1", "P2", "P3"}}
+	for track, want := range wantBursts {
+		if got := bursts[track]; !slices.Equal(got, want) {
+			t.Errorf("slices of %s = %v, want %v", track, got, want)
+		}
+	}
+	// a counter event is emitted only when the length changes
+	wantCounters := map[string][]int{"CPUs queue": {0, 1, 2, 1, 0}, "IO1 queue": {0, 1, 0}}
+	for name, want := range wantCounters {
+		if got := counters[name]; !slices.Equal(got, want) {
+			t.Errorf("counter %s = %v, want %v", name, got, want)
+		}
+	}
+	// arrival and finish of every process
+	if instants != 6 {
+		t.Errorf("%d instant events, want 6", instants)
+	}
+	if trace.DisplayTimeUnit != "ms" {
+		t.Errorf("display time unit %q", trace.DisplayTimeUnit)
+	}
+}
@@ -0,0 +1,69 @@
+package trace
+
+import (
+	"encoding/json"
+	"io"
+
+	m "github.com/Moleus/os-solver/pkg/machine"
+)
+
+// EventRecord - one line of event log. Process and burst are one based like in other outputs
+type EventRecord struct {
+	Tick     int    `json:"tick"`
+	Event    string `json:"event"`
+	Process  int    `json:"process"`
+	Burst    int    `json:"burst"`
+	Resource string `json:"resource,omitempty"`
+	Queue    string `json:"queue,omitempty"`
+	State    string `json:"state"`
+	Reason   string `json:"reason,omitempty"`
+}
+
+func NewEventRecord(e m.Event) EventRecord {
+	return EventRecord{
+		Tick:     e.Tick,
+		Event:    e.Type.String(),
+		Process:  e.ProcId + 1,
+		Burst:    e.TaskIndex + 1,
+		Resource: e.Resource,
+		Queue:    e.Queue,
+		State:    e.State.String(),
+		Reason:   e.Reason,
+	}
+}
+
+// EventLog - observer writing every event as a json object per line while the simulation runs
+type EventLog struct {
+	m.EventFunc
+	enc *json.Encoder
+	err error
+}
+
+func NewEventLog(w io.Writer) *EventLog {
+	l := &EventLog{enc: json.NewEncoder(w)}
+	// reasons quote expressions like best < score
+	l.enc.SetEscapeHTML(false)
+	l.EventFunc = l.write
+	return l
+}
+
+func (l *EventLog) write(e m.Event) {
+	if l.err != nil {
+		return
+	}
+	l.err = l.enc.Encode(NewEventRecord(e))
+}
+
+// Err - first write error, the rest of events is dropped after it
+func (l *EventLog) Err() error {
+	return l.err
+}
+
+// WriteEvents - events of a finished run in the same format as EventLog
+func WriteEvents(w io.Writer, events []m.Event) error {
+	l := NewEventLog(w)
+	for _, e := range events {
+		l.write(e)
+	}
+	return l.Err()
+}
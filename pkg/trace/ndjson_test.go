@@ -0,0 +1,35 @@
+package trace
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+func TestWriteEvents(t *testing.T) {
+	res := runTiny(t)
+	var buf bytes.Buffer
+	if err := WriteEvents(&buf, res.Events); err != nil {
+		t.Fatal(err)
+	}
+
+	lines := 0
+	scanner := bufio.NewScanner(bytes.NewReader(buf.Bytes()))
+	for scanner.Scan() {
+		var record EventRecord
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			t.Fatalf("line %d: %v", lines+1, err)
+		}
+		if lines >= len(res.Events) {
+			t.Fatalf("more lines than %d events", len(res.Events))
+		}
+		if want := NewEventRecord(res.Events[lines]); record != want {
+			t.Errorf("line %d = %+v, want %+v", lines+1, record, want)
+		}
+		lines++
+	}
+	if lines != len(res.Events) {
+		t.Errorf("%d lines, want %d", lines, len(res.Events))
+	}
+}
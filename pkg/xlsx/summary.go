@@ -0,0 +1,285 @@
+package xlsx
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/Moleus/os-solver/pkg/sim"
+	"github.com/xuri/excelize/v2"
+)
+
+const (
+	SummarySheet = "Summary"
+	// trTsMetric - name of mean Tr/Ts in sim.SweepMetrics
+	trTsMetric       = "Tr/Ts mean"
+	utilisationTitle = "Utilisation"
+)
+
+// summaryTable - value of every row name per algorithm
+type summaryTable struct {
+	title  string
+	rows   []string
+	values map[string]map[string]float64
+}
+
+func (t *summaryTable) set(algorithm string, row string, value float64) {
+	if t.values[algorithm] == nil {
+		t.values[algorithm] = make(map[string]float64)
+	}
+	if !slices.Contains(t.rows, row) {
+		t.rows = append(t.rows, row)
+	}
+	t.values[algorithm][row] = value
+}
+
+// PrintSummary - adds aggregate metrics and resource utilisation of the result to the Summary sheet, which
+// compares every algorithm and settings written to the workbook, and redraws its charts.
+// A column per run is labelled by summaryColumn, a rerun with the same settings replaces its column and moves it to the end.
+// Queue length chart uses the <algorithm>_queues sheets, so it should be called after they are written
+func PrintSummary(f *excelize.File, algorithm string, res sim.Result) {
+	algorithms, tables := readSummary(f)
+	column := summaryColumn(algorithm, res.Config)
+	algorithms = append(slices.DeleteFunc(algorithms, func(a string) bool { return a == column }), column)
+	for _, metric := range sim.SweepMetrics {
+		tables[0].set(column, metric.Name, metric.Value(res.Metrics))
+	}
+	for _, r := range res.Metrics.Resources {
+		tables[1].set(column, r.Name, r.Utilisation)
+	}
+
+	resetSheet(f, SummarySheet)
+	deleteCharts(f)
+	numbers, err := f.NewStyle(&excelize.Style{NumFmt: 2})
+	if err != nil {
+		panic(err)
+	}
+	row := 1
+	tableRows := make([]int, len(tables))
+	for i, t := range tables {
+		tableRows[i] = row
+		printRow(f, SummarySheet, 0, row, append([]string{t.title}, algorithms...))
+		for _, name := range t.rows {
+			row++
+			printRow(f, SummarySheet, 0, row, []string{name})
+			for col, alg := range algorithms {
+				value, ok := t.values[alg][name]
+				if !ok {
+					continue
+				}
+				cell := cellName(col+2, row)
+				if err := f.SetCellFloat(SummarySheet, cell, value, -1, 64); err != nil {
+					panic(err)
+				}
+				if err := f.SetCellStyle(SummarySheet, cell, cell, numbers); err != nil {
+					panic(err)
+				}
+			}
+		}
+		row += 2
+	}
+	if err := f.SetColWidth(SummarySheet, "A", "A", 20); err != nil {
+		panic(err)
+	}
+
+	chartCol := len(algorithms) + 3
+	algorithmsRef := rangeRef(SummarySheet, 2, 1, len(algorithms)+1, 1)
+	charts := []*excelize.Chart{trTsChart(tables[0], tableRows[0], algorithmsRef, len(algorithms))}
+	charts = append(charts, utilisationChart(tables[1], tableRows[1], algorithmsRef, len(algorithms)))
+	charts = append(charts, queueLengthChart(f, algorithms))
+	chartRow := 1
+	for _, chart := range charts {
+		if chart == nil || len(chart.Series) == 0 {
+			continue
+		}
+		chart.Dimension = excelize.ChartDimension{Width: 640, Height: 320}
+		if err := f.AddChart(SummarySheet, cellName(chartCol, chartRow), chart); err != nil {
+			panic(err)
+		}
+		chartRow += 17
+	}
+}
+
+// summaryColumn - algorithm followed by settings which affect results, e.g. "rr quantum=2 cpus=2 switch=1".
+// The algorithm comes first, so the column can be traced to the <algorithm> sheets
+func summaryColumn(algorithm string, config sim.Config) string {
+	settings := []string{algorithm}
+	// only declared params affect the algorithm, the rest are ignored by it
+	if algo, ok := sim.LookupAlgorithm(config.Algorithm); ok {
+		for _, param := range algo.Params {
+			value, ok := config.Params[param.Name]
+			if !ok {
+				value = param.Default
+			}
+			settings = append(settings, param.Name+"="+value)
+		}
+	}
+	settings = append(settings, fmt.Sprintf("cpus=%d", config.CPUs), fmt.Sprintf("devices=%d", config.Devices))
+	if arrival, ok := config.Arrival.(sim.FixedInterval); ok {
+		settings = append(settings, fmt.Sprintf("interval=%d", arrival.Interval))
+	}
+	settings = append(settings, fmt.Sprintf("switch=%d", config.SwitchCost), "tie-break="+config.TieBreak.String())
+	return strings.Join(settings, " ")
+}
+
+// summaryAlgorithm - algorithm of the column written by summaryColumn
+func summaryAlgorithm(column string) string {
+	algorithm, _, _ := strings.Cut(column, " ")
+	return algorithm
+}
+
+// deleteCharts - removes drawing and chart parts. Deleting a sheet keeps parts of its charts in the package,
+// Summary is the only sheet with charts, so after its reset all of them are orphans
+func deleteCharts(f *excelize.File) {
+	isChartPart := func(name string) bool {
+		name = strings.TrimPrefix(name, "/")
+		return strings.HasPrefix(name, "xl/drawings/") || strings.HasPrefix(name, "xl/charts/")
+	}
+	for _, parts := range []*sync.Map{&f.Pkg, &f.Drawings, &f.Relationships} {
+		parts.Range(func(key, _ any) bool {
+			if isChartPart(key.(string)) {
+				parts.Delete(key)
+			}
+			return true
+		})
+	}
+	if f.ContentTypes == nil {
+		return
+	}
+	overrides := f.ContentTypes.Overrides[:0]
+	for _, o := range f.ContentTypes.Overrides {
+		if !isChartPart(o.PartName) {
+			overrides = append(overrides, o)
+		}
+	}
+	f.ContentTypes.Overrides = overrides
+}
+
+// readSummary - algorithms and tables of the Summary sheet written by previous runs
+func readSummary(f *excelize.File) ([]string, []*summaryTable) {
+	tables := []*summaryTable{
+		{title: "Metric", values: make(map[string]map[string]float64)},
+		{title: utilisationTitle, values: make(map[string]map[string]float64)},
+	}
+	var algorithms []string
+	if idx, err := f.GetSheetIndex(SummarySheet); err != nil || idx == -1 {
+		return algorithms, tables
+	}
+	rows, err := f.GetRows(SummarySheet, excelize.Options{RawCellValue: true})
+	if err != nil {
+		panic(err)
+	}
+	table := -1
+	for i, row := range rows {
+		switch {
+		case len(row) == 0:
+			continue
+		case i == 0 || len(rows[i-1]) == 0:
+			// header of the next table
+			table++
+			if table == 0 {
+				algorithms = append(algorithms, row[1:]...)
+			}
+			continue
+		case table >= len(tables):
+			continue
+		}
+		for col, value := range row[1:] {
+			v, err := strconv.ParseFloat(value, 64)
+			if err != nil || col >= len(algorithms) {
+				continue
+			}
+			tables[table].set(algorithms[col], row[0], v)
+		}
+		if !slices.Contains(tables[table].rows, row[0]) {
+			tables[table].rows = append(tables[table].rows, row[0])
+		}
+	}
+	return algorithms, tables
+}
+
+func trTsChart(t *summaryTable, headerRow int, algorithmsRef string, algorithms int) *excelize.Chart {
+	for i, name := range t.rows {
+		if name != trTsMetric {
+			continue
+		}
+		row := headerRow + 1 + i
+		return &excelize.Chart{
+			Type:   excelize.Col,
+			Title:  []excelize.RichTextRun{{Text: "Mean Tr/Ts per algorithm"}},
+			Legend: excelize.ChartLegend{Position: "none"},
+			Series: []excelize.ChartSeries{{
+				Name:       rangeRef(SummarySheet, 1, row, 1, row),
+				Categories: algorithmsRef,
+				Values:     rangeRef(SummarySheet, 2, row, algorithms+1, row),
+			}},
+		}
+	}
+	return nil
+}
+
+func utilisationChart(t *summaryTable, headerRow int, algorithmsRef string, algorithms int) *excelize.Chart {
+	chart := &excelize.Chart{
+		Type:   excelize.Col,
+		Title:  []excelize.RichTextRun{{Text: "Utilisation per resource"}},
+		Legend: excelize.ChartLegend{Position: "bottom"},
+	}
+	for i := range t.rows {
+		row := headerRow + 1 + i
+		chart.Series = append(chart.Series, excelize.ChartSeries{
+			Name:       rangeRef(SummarySheet, 1, row, 1, row),
+			Categories: algorithmsRef,
+			Values:     rangeRef(SummarySheet, 2, row, algorithms+1, row),
+		})
+	}
+	return chart
+}
+
+// queueLengthChart - ready queue length over ticks of the latest run of every algorithm with a queues sheet,
+// ticks of the longest run label the axis
+func queueLengthChart(f *excelize.File, algorithms []string) *excelize.Chart {
+	chart := &excelize.Chart{
+		Type:   excelize.Line,
+		Title:  []excelize.RichTextRun{{Text: "Ready queue length"}},
+		Legend: excelize.ChartLegend{Position: "bottom"},
+	}
+	ticks := ""
+	longest := 0
+	for col, alg := range algorithms {
+		// queues sheet holds the latest run of the algorithm, which is its rightmost column
+		if slices.ContainsFunc(algorithms[col+1:], func(a string) bool { return summaryAlgorithm(a) == summaryAlgorithm(alg) }) {
+			continue
+		}
+		sheet := summaryAlgorithm(alg) + "_queues"
+		if idx, err := f.GetSheetIndex(sheet); err != nil || idx == -1 {
+			continue
+		}
+		rows, err := f.GetRows(sheet)
+		if err != nil {
+			panic(err)
+		}
+		if len(rows) < 2 {
+			continue
+		}
+		if len(rows) > longest {
+			longest = len(rows)
+			ticks = rangeRef(sheet, 1, 2, 1, len(rows))
+		}
+		chart.Series = append(chart.Series, excelize.ChartSeries{
+			Name:   rangeRef(SummarySheet, col+2, 1, col+2, 1),
+			Values: rangeRef(sheet, 2, 2, 2, len(rows)),
+			Marker: excelize.ChartMarker{Symbol: "none"},
+		})
+	}
+	for i := range chart.Series {
+		chart.Series[i].Categories = ticks
+	}
+	return chart
+}
+
+// rangeRef - absolute reference to cells from col1,row1 to col2,row2 (one based) of the sheet
+func rangeRef(sheet string, col1 int, row1 int, col2 int, row2 int) string {
+	return fmt.Sprintf("'%s'!%s:%s", strings.ReplaceAll(sheet, "'", "''"), absCellName(col1, row1), absCellName(col2, row2))
+}
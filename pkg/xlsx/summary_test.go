@@ -0,0 +1,109 @@
+package xlsx
+
+import (
+	"archive/zip"
+	"context"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/Moleus/os-solver/pkg/report"
+	"github.com/Moleus/os-solver/pkg/sim"
+	"github.com/xuri/excelize/v2"
+)
+
+// summaryRun - writes the sheets of a run the way cmd does and saves the workbook
+func summaryRun(t *testing.T, fileName string, config sim.Config) {
+	t.Helper()
+	workload, err := sim.ParseWorkload(strings.NewReader("CPU(3);IO1(2);CPU(1);IO1(1);\nCPU(2);IO1(2);\nCPU(4);IO1(1);\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err := sim.Run(context.Background(), config, workload)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f := GetF(fileName, config.Algorithm)
+	PrintSheet(f, config.Algorithm+"_queues", report.QueueRows(res.Header, res.Timeline))
+	PrintSummary(f, config.Algorithm, res)
+	if err := f.SaveAs(fileName); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// chartParts - count of chart and drawing parts in the saved package
+func chartParts(t *testing.T, fileName string) (charts int, drawings int) {
+	t.Helper()
+	r, err := zip.OpenReader(fileName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	for _, part := range r.File {
+		if strings.Contains(part.Name, "_rels/") {
+			continue
+		}
+		switch {
+		case strings.HasPrefix(part.Name, "xl/charts/"):
+			charts++
+		case strings.HasPrefix(part.Name, "xl/drawings/"):
+			drawings++
+		}
+	}
+	return charts, drawings
+}
+
+func TestPrintSummaryReruns(t *testing.T) {
+	fileName := filepath.Join(t.TempDir(), "report.xlsx")
+	fcfs := sim.DefaultConfig()
+	fcfs.CPUs = 1
+	fcfs.Devices = 1
+	rr2 := fcfs
+	rr2.Algorithm = "rr"
+	rr2.Params = sim.Params{"quantum": "2"}
+	rr1 := rr2
+	rr1.Params = sim.Params{"quantum": "1"}
+
+	steps := []struct {
+		name   string
+		config sim.Config
+		sheets []string
+		// columns - Summary header after the run
+		columns []sim.Config
+	}{
+		{"first run", fcfs, []string{"fcfs", "fcfs_queues", SummarySheet}, []sim.Config{fcfs}},
+		{"new algorithm appends", rr2, []string{"fcfs", "fcfs_queues", "rr", "rr_queues", SummarySheet}, []sim.Config{fcfs, rr2}},
+		// rewritten sheets move to the end
+		{"same settings replace", fcfs, []string{"fcfs", "rr", "rr_queues", "fcfs_queues", SummarySheet}, []sim.Config{rr2, fcfs}},
+		{"new settings append", rr1, []string{"fcfs", "rr", "fcfs_queues", "rr_queues", SummarySheet}, []sim.Config{rr2, fcfs, rr1}},
+	}
+	for _, step := range steps {
+		summaryRun(t, fileName, step.config)
+
+		f, err := excelize.OpenFile(fileName)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := f.GetSheetList(); !slices.Equal(got, step.sheets) {
+			t.Errorf("%s: sheets %v, want %v", step.name, got, step.sheets)
+		}
+		rows, err := f.GetRows(SummarySheet)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := []string{"Metric"}
+		for _, config := range step.columns {
+			want = append(want, summaryColumn(config.Algorithm, config))
+		}
+		if !slices.Equal(rows[0], want) {
+			t.Errorf("%s: columns %q, want %q", step.name, rows[0], want)
+		}
+		f.Close()
+
+		// Tr/Ts, utilisation and queue length, older parts are dropped
+		if charts, drawings := chartParts(t, fileName); charts != 3 || drawings != 1 {
+			t.Errorf("%s: %d charts and %d drawings, want 3 and 1", step.name, charts, drawings)
+		}
+	}
+}
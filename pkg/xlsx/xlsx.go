@@ -3,22 +3,39 @@ package xlsx
 import (
 	"errors"
 	"fmt"
+	"github.com/Moleus/os-solver/pkg/gantt"
 	m "github.com/Moleus/os-solver/pkg/machine"
 	"github.com/xuri/excelize/v2"
 	"os"
 	"strconv"
+	"strings"
 )
 
-const countOfHardcodedColors = 10
-
 func printRow(f *excelize.File, sheet string, offset int, row int, values []string) {
 	for pos, val := range values {
-		err := f.SetCellValue(sheet, string(rune('A'+offset+pos))+strconv.Itoa(row), val)
+		cell, err := excelize.CoordinatesToCellName(offset+pos+1, row)
 		if err != nil {
-			return
+			panic(err)
 		}
+		setCell(f, sheet, cell, val)
+	}
+}
+
+// setCell - numbers are written as numeric cells so that charts and formulas can use them
+func setCell(f *excelize.File, sheet string, cell string, val string) {
+	var err error
+	if i, convErr := strconv.Atoi(val); convErr == nil {
+		err = f.SetCellInt(sheet, cell, i)
+	} else if v, convErr := strconv.ParseFloat(val, 64); convErr == nil {
+		err = f.SetCellFloat(sheet, cell, v, -1, 64)
+	} else {
+		err = f.SetCellStr(sheet, cell, val)
+	}
+	if err != nil {
+		panic(err)
 	}
 }
+
 func GetF(fileName string, sheet string) *excelize.File {
 	var f *excelize.File
 	if _, err := os.Stat(fileName); errors.Is(err, os.ErrNotExist) {
@@ -45,84 +62,309 @@ func GetF(fileName string, sheet string) *excelize.File {
 	}
 	return f
 }
-func setStyle(f *excelize.File, spreed string, column string, row string, val string, colors [countOfHardcodedColors]int) {
-	if val == "-" {
-		return
+
+// GenerateStyles - fill style per process, colours match svg and html charts
+func GenerateStyles(f *excelize.File, procs int) []int {
+	styles := make([]int, procs)
+	for i, color := range gantt.Palette(procs) {
+		style, err := f.NewStyle(&excelize.Style{
+			Fill:      excelize.Fill{Type: "pattern", Color: []string{color}, Pattern: 1},
+			Alignment: &excelize.Alignment{Horizontal: "center"},
+		})
+		if err != nil {
+			panic(err)
+		}
+		styles[i] = style
 	}
-	i, err := strconv.Atoi(val)
+	return styles
+}
+
+// setProcValue - writes process id or - for idle to the cell at col and row (one based), coloured by process
+func setProcValue(f *excelize.File, sheet string, col int, row int, val string, styles []int) {
+	cell, err := excelize.CoordinatesToCellName(col, row)
 	if err != nil {
 		panic(err)
 	}
-	if i > countOfHardcodedColors {
+	setCell(f, sheet, cell, val)
+	id, err := strconv.Atoi(val)
+	if err != nil || id < 1 || id > len(styles) {
 		return
 	}
-	err = f.SetCellStyle(spreed, column+row, column+row, colors[i])
-	if err != nil {
+	if err := f.SetCellStyle(sheet, cell, cell, styles[id-1]); err != nil {
 		panic(err)
 	}
 }
 
-func GenerateStyles(f *excelize.File) [countOfHardcodedColors]int {
-	colors := [countOfHardcodedColors]string{"E0EBF5", "#93e476", "#efb2b9", "#6a74eb", "#f0b1e4", "#c1b1f0", "#ead669", "#ebaa6a", "#eb836a", "#6aeb71"}
-	var styles [countOfHardcodedColors]int
-	for i, color := range colors {
-		style, err := f.NewStyle(&excelize.Style{
-			Fill: excelize.Fill{Type: "pattern", Color: []string{color}, Pattern: 1},
-		})
-		if err != nil {
-			fmt.Println(err)
+// PrintTimeline - header and a row per tick with process on every CPU and IO device.
+// Replaces previous contents of the sheet, so stats of an earlier run don't survive
+func PrintTimeline(f *excelize.File, sheet string, header m.DumpState, timeline []m.DumpState, styles []int) {
+	resetSheet(f, sheet)
+	index, err := f.GetSheetIndex(sheet)
+	if err != nil {
+		panic(err)
+	}
+	f.SetActiveSheet(index)
+	printRow(f, sheet, 0, 1, append(append([]string{header.Tick}, header.CpusState...), header.IoStates...))
+	for i, state := range timeline {
+		row := i + 2
+		printRow(f, sheet, 0, row, []string{state.Tick})
+		for pos, val := range append(append([]string{}, state.CpusState...), state.IoStates...) {
+			setProcValue(f, sheet, pos+2, row, val, styles)
 		}
-		styles[i] = style
 	}
-	return styles
 }
 
-func SnapshotStateXlsx(f *excelize.File, sheet string, tick string, cpusStateString []string, io1State string, io2State string, colors [countOfHardcodedColors]int, cpuCount int) {
-	err := f.SetCellValue(sheet, fmt.Sprintf("A%s", tick), tick)
-	if err != nil {
-		return
+// PrintGantt - horizontal timeline on its own sheet, replacing previous one: a row per CPU and IO device
+// and a narrow column per tick. Ticks past the last column of a sheet are dropped
+func PrintGantt(f *excelize.File, sheet string, header m.DumpState, timeline []m.DumpState, styles []int) {
+	resetSheet(f, sheet)
+	chart := gantt.ByResource(header, timeline)
+	ticks := min(chart.Ticks, excelize.MaxColumns-1)
+	printRow(f, sheet, 0, 1, []string{header.Tick})
+	for tick := 0; tick < ticks; tick++ {
+		printRow(f, sheet, tick+1, 1, []string{strconv.Itoa(tick)})
 	}
-	for pos, val := range cpusStateString {
-		err := f.SetCellValue(sheet, string('A'+pos+1)+tick, val)
-		if err != nil {
-			return
+	for i, r := range chart.Rows {
+		printRow(f, sheet, 0, i+2, []string{r.Name})
+		for tick, label := range r.Cells[:ticks] {
+			if label != "" {
+				setProcValue(f, sheet, tick+2, i+2, label, styles)
+			}
 		}
-		setStyle(f, sheet, string('A'+pos+1), tick, val, colors)
 	}
-	err = f.SetCellValue(sheet, string('A'+cpuCount+1)+tick, io1State)
-	if err != nil {
+	if ticks == 0 {
 		return
 	}
-	setStyle(f, sheet, string('A'+cpuCount+1), tick, io1State, colors)
-	err = f.SetCellValue(sheet, string('A'+cpuCount+2)+tick, io2State)
+	last, err := excelize.ColumnNumberToName(ticks + 1)
 	if err != nil {
-		return
+		panic(err)
+	}
+	if err := f.SetColWidth(sheet, "B", last, 3.5); err != nil {
+		panic(err)
 	}
-	setStyle(f, sheet, string('A'+cpuCount+2), tick, io2State, colors)
+	if err := f.SetPanes(sheet, &excelize.Panes{Freeze: true, XSplit: 1, TopLeftCell: "B1", ActivePane: "topRight"}); err != nil {
+		panic(err)
+	}
+}
+
+// statsColumn - column of process stats. Formula is built from cell names of the process row,
+// value is written when there is no formula. Total columns get a sum below the mean row
+type statsColumn struct {
+	header  string
+	value   func(stats m.ProcStats) int
+	formula func(c statsCells) string
+	total   bool
+}
+
+// statsCells - cell names used by formulas of one process row
+type statsCells struct {
+	id, arrival, service, finish, turnaround, cpuService, ioService, switchOverhead string
+	ticks, cpus, ios                                                                string
+	// resources - range of every CPU and IO column
+	resources []string
+}
+
+var statsColumns = []statsColumn{
+	{header: "Process", value: func(s m.ProcStats) int { return s.ProcId + 1 }},
+	{header: "Arrival", value: func(s m.ProcStats) int { return s.EntranceTime }},
+	{header: "Service", total: true, formula: func(c statsCells) string {
+		return fmt.Sprintf("%s+%s", c.cpuService, c.ioService)
+	}},
+	{header: "Waiting", total: true, formula: func(c statsCells) string {
+		return fmt.Sprintf("%s-%s-%s", c.turnaround, c.service, c.switchOverhead)
+	}},
+	// last tick on which the process occupied any resource. Functions added after Excel 2007
+	// are stored with _xlfn. prefix, without it spreadsheets show #NAME?
+	{header: "Finish_time", formula: func(c statsCells) string {
+		last := make([]string, len(c.resources))
+		for i, r := range c.resources {
+			last[i] = fmt.Sprintf("_xlfn.MAXIFS(%s,%s,%s)", c.ticks, r, c.id)
+		}
+		return fmt.Sprintf("MAX(%s)", strings.Join(last, ","))
+	}},
+	{header: "Turnaround_(Tr)", total: true, formula: func(c statsCells) string {
+		return fmt.Sprintf("%s-%s+1", c.finish, c.arrival)
+	}},
+	{header: "Tr/Ts", formula: func(c statsCells) string {
+		return fmt.Sprintf("%s/%s", c.turnaround, c.service)
+	}},
+	{header: "Response", total: true, value: func(s m.ProcStats) int { return s.ResponseTime }},
+	{header: "Ready_wait", total: true, value: func(s m.ProcStats) int { return s.ReadyWaitTime }},
+	{header: "IO_wait", total: true, value: func(s m.ProcStats) int { return s.IOWaitTime }},
+	// ticks of context switches are shown on the CPU too. Criteria is a text, which matches numeric
+	// cells in spreadsheets and in the excelize calc engine
+	{header: "CPU_service", total: true, formula: func(c statsCells) string {
+		return fmt.Sprintf("COUNTIF(%s,\"\"&%s)-%s", c.cpus, c.id, c.switchOverhead)
+	}},
+	{header: "IO_service", total: true, formula: func(c statsCells) string {
+		return fmt.Sprintf("COUNTIF(%s,\"\"&%s)", c.ios, c.id)
+	}},
+	{header: "Dispatches", total: true, value: func(s m.ProcStats) int { return s.Dispatches }},
+	{header: "Preemptions", total: true, value: func(s m.ProcStats) int { return s.Preemptions }},
+	{header: "IO_yields", total: true, value: func(s m.ProcStats) int { return s.IOYields }},
+	{header: "Migrations", total: true, value: func(s m.ProcStats) int { return s.Migrations }},
+	{header: "Longest_ready_wait", value: func(s m.ProcStats) int { return s.LongestReadyWait }},
+	{header: "Switch_overhead", total: true, value: func(s m.ProcStats) int { return s.SwitchOverheadTime }},
 }
-func PrintProcsStats(f *excelize.File, sheet string, procs []*m.Process, offset int) {
-	headers := []string{"Process", "Arrival", "Service", "Waiting", "Finish_time", "Turnaround_(Tr)", "Tr/Ts"}
+
+// PrintProcsStats - per-process stats starting at column offset (0 is A), followed by mean and total rows.
+// Service, waiting, finish and turnaround times are formulas over the timeline written by PrintTimeline,
+// the rest are values of the simulation. Returns the first row after the table
+func PrintProcsStats(f *excelize.File, sheet string, procs []m.ProcStats, header m.DumpState, ticks int, offset int) int {
+	headers := make([]string, len(statsColumns))
+	for i, c := range statsColumns {
+		headers[i] = c.header
+	}
 	printRow(f, sheet, offset, 1, headers)
 
-	for pos, proc := range procs {
-		stats := proc.GetStats()
-		normalizedTurnaround := float64(stats.TurnaroundTime) / float64(stats.ServiceTime)
+	cpus, ios := len(header.CpusState), len(header.IoStates)
+	lastTick := ticks + 1
+	timeline := func(from, to int) string {
+		return fmt.Sprintf("%s:%s", absCellName(from, 2), absCellName(to, lastTick))
+	}
+	column := func(name string) int {
+		for i, c := range statsColumns {
+			if c.header == name {
+				return offset + i + 1
+			}
+		}
+		panic("no stats column " + name)
+	}
+	for pos, stats := range procs {
+		row := pos + 2
+		cells := statsCells{
+			id:             cellName(column("Process"), row),
+			arrival:        cellName(column("Arrival"), row),
+			service:        cellName(column("Service"), row),
+			finish:         cellName(column("Finish_time"), row),
+			turnaround:     cellName(column("Turnaround_(Tr)"), row),
+			cpuService:     cellName(column("CPU_service"), row),
+			ioService:      cellName(column("IO_service"), row),
+			switchOverhead: cellName(column("Switch_overhead"), row),
+			ticks:          timeline(1, 1),
+			cpus:           timeline(2, cpus+1),
+			ios:            timeline(cpus+2, cpus+ios+1),
+		}
+		for col := 2; col <= cpus+ios+1; col++ {
+			cells.resources = append(cells.resources, timeline(col, col))
+		}
+		for i, c := range statsColumns {
+			cell := cellName(offset+i+1, row)
+			var err error
+			if c.formula != nil {
+				err = f.SetCellFormula(sheet, cell, c.formula(cells))
+			} else {
+				err = f.SetCellInt(sheet, cell, c.value(stats))
+			}
+			if err != nil {
+				panic(err)
+			}
+		}
+	}
+
+	meanRow, totalRow := len(procs)+2, len(procs)+3
+	printRow(f, sheet, offset, meanRow, []string{"Mean"})
+	printRow(f, sheet, offset, totalRow, []string{"Total"})
+	numbers, err := f.NewStyle(&excelize.Style{NumFmt: 2})
+	if err != nil {
+		panic(err)
+	}
+	for i, c := range statsColumns[1:] {
+		col := offset + i + 2
+		values := fmt.Sprintf("%s:%s", cellName(col, 2), cellName(col, len(procs)+1))
+		mean := cellName(col, meanRow)
+		if err := f.SetCellFormula(sheet, mean, fmt.Sprintf("AVERAGE(%s)", values)); err != nil {
+			panic(err)
+		}
+		if err := f.SetCellStyle(sheet, mean, mean, numbers); err != nil {
+			panic(err)
+		}
+		if c.total {
+			if err := f.SetCellFormula(sheet, cellName(col, totalRow), fmt.Sprintf("SUM(%s)", values)); err != nil {
+				panic(err)
+			}
+		}
+	}
+	return totalRow + 1
+}
 
+// resetSheet - creates empty sheet, dropping previous one with the same name
+func resetSheet(f *excelize.File, sheet string) {
+	// excelize keeps the only sheet of a workbook, a placeholder lets it go
+	placeholder := ""
+	if idx, _ := f.GetSheetIndex(sheet); idx != -1 && f.SheetCount == 1 {
+		placeholder = sheet + "_reset"
+		if _, err := f.NewSheet(placeholder); err != nil {
+			panic(err)
+		}
+	}
+	if err := f.DeleteSheet(sheet); err != nil {
+		panic(err)
+	}
+	if _, err := f.NewSheet(sheet); err != nil {
+		panic(err)
+	}
+	if placeholder != "" {
+		if err := f.DeleteSheet(placeholder); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// PrintSheet - writes rows to their own sheet, replacing previous one
+func PrintSheet(f *excelize.File, sheet string, rows [][]string) {
+	resetSheet(f, sheet)
+	PrintTable(f, sheet, rows, 0, 1)
+}
+
+// PrintTaskStats - writes per-burst stats to its own sheet, replacing previous one
+func PrintTaskStats(f *excelize.File, sheet string, tasks []m.TaskStats) {
+	resetSheet(f, sheet)
+	headers := []string{"Process", "Burst", "Resource", "Duration", "Ready", "Start", "Waiting", "Preemptions", "Switch_overhead", "Finish"}
+	printRow(f, sheet, 0, 1, headers)
+	for pos, t := range tasks {
 		values := []string{
-			fmt.Sprintf("%v", stats.ProcId),
-			fmt.Sprintf("%v", stats.EntranceTime),
-			fmt.Sprintf("%v", stats.ServiceTime),
-			fmt.Sprintf("%v", stats.ReadyOrBlockedTime),
-			fmt.Sprintf("%v", stats.ExitTime),
-			fmt.Sprintf("%v", stats.TurnaroundTime),
-			fmt.Sprintf("%v", normalizedTurnaround),
+			fmt.Sprintf("%v", t.ProcId+1),
+			fmt.Sprintf("%v", t.TaskIndex+1),
+			t.ResourceType.String(),
+			fmt.Sprintf("%v", t.Duration),
+			fmt.Sprintf("%v", t.ReadyTime),
+			fmt.Sprintf("%v", t.StartTime),
+			fmt.Sprintf("%v", t.WaitTime),
+			fmt.Sprintf("%v", t.Preemptions),
+			fmt.Sprintf("%v", t.SwitchOverhead),
+			fmt.Sprintf("%v", t.FinishTime),
 		}
+		printRow(f, sheet, 0, pos+2, values)
+	}
+}
 
-		printRow(f, sheet, offset, pos+2, values)
+// PrintTable - writes rows starting at column offset (0 is A) and row
+func PrintTable(f *excelize.File, sheet string, rows [][]string, offset int, row int) {
+	for i, values := range rows {
+		printRow(f, sheet, offset, row+i, values)
 	}
 }
+
 func SaveReport(f *excelize.File, fileName string) {
 	if err := f.SaveAs(fileName); err != nil {
 		fmt.Println(err)
 	}
 }
+
+func cellName(col int, row int) string {
+	cell, err := excelize.CoordinatesToCellName(col, row)
+	if err != nil {
+		panic(err)
+	}
+	return cell
+}
+
+// absCellName - cell name with $ before column and row, e.g. $B$2
+func absCellName(col int, row int) string {
+	cell, err := excelize.CoordinatesToCellName(col, row, true)
+	if err != nil {
+		panic(err)
+	}
+	return cell
+}
@@ -0,0 +1,67 @@
+package xlsx
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"strconv"
+	"testing"
+
+	m "github.com/Moleus/os-solver/pkg/machine"
+	"github.com/Moleus/os-solver/pkg/sim"
+	"github.com/xuri/excelize/v2"
+)
+
+// statsValues - expected values of formula columns
+var statsValues = map[string]func(s m.ProcStats) float64{
+	"Service":         func(s m.ProcStats) float64 { return float64(s.ServiceTime) },
+	"Waiting":         func(s m.ProcStats) float64 { return float64(s.ReadyOrBlockedTime) },
+	"Finish_time":     func(s m.ProcStats) float64 { return float64(s.ExitTime) },
+	"Turnaround_(Tr)": func(s m.ProcStats) float64 { return float64(s.TurnaroundTime) },
+	"Tr/Ts":           func(s m.ProcStats) float64 { return float64(s.TurnaroundTime) / float64(s.ServiceTime) },
+	"CPU_service":     func(s m.ProcStats) float64 { return float64(s.CPUServiceTime) },
+	"IO_service":      func(s m.ProcStats) float64 { return float64(s.IOServiceTime) },
+}
+
+func TestProcsStatsFormulas(t *testing.T) {
+	workload := sim.GenerateWorkload(rand.New(rand.NewSource(1)), sim.DefaultWorkloadSpec(), 2)
+	for _, algorithm := range []string{"fcfs", "rr4", "srt"} {
+		for _, switchCost := range []int{0, 1} {
+			config := sim.DefaultConfig()
+			config.CPUs = 2
+			config.Algorithm = algorithm
+			config.SwitchCost = switchCost
+			res, err := sim.Run(context.Background(), config, workload)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			f := excelize.NewFile()
+			sheet := "Sheet1"
+			PrintTimeline(f, sheet, res.Header, res.Timeline, GenerateStyles(f, len(res.Procs)))
+			offset := 1 + config.CPUs + config.Devices + 1
+			PrintProcsStats(f, sheet, res.Procs, res.Header, len(res.Timeline), offset)
+
+			for i, c := range statsColumns {
+				if c.formula == nil {
+					continue
+				}
+				want, ok := statsValues[c.header]
+				if !ok {
+					t.Fatalf("no expected value of formula column %s", c.header)
+				}
+				for pos, stats := range res.Procs {
+					cell := cellName(offset+i+1, pos+2)
+					value, err := f.CalcCellValue(sheet, cell)
+					if err != nil {
+						t.Fatalf("%s switch %d: %s of process %d: %v", algorithm, switchCost, c.header, stats.ProcId+1, err)
+					}
+					got, err := strconv.ParseFloat(value, 64)
+					if err != nil || math.Abs(got-want(stats)) > 1e-6 {
+						t.Errorf("%s switch %d: %s of process %d is %q, want %v", algorithm, switchCost, c.header, stats.ProcId+1, value, want(stats))
+					}
+				}
+			}
+		}
+	}
+}
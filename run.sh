@@ -14,4 +14,4 @@ mkdir -p $stats
 ./main -cpus 2 -algo spn  -input $variant -log warn -output $output/spn.txt  -procStats $stats/spn.stats -export-xlsx $output/report.xlsx
 ./main -cpus 2 -algo srt  -input $variant -log warn -output $output/srt.txt  -procStats $stats/srt.stats -export-xlsx $output/report.xlsx
 ./main -cpus 2 -algo hrrn -input $variant -log warn -output $output/hrrn.txt -procStats $stats/hrrn.stats -export-xlsx $output/report.xlsx
-
+./main -cpus 2 -algo rr   -input $variant -log warn -sweep quantum=1..8 -sweep switch-cost=0,1 -sweep-output $stats/rr_sweep.csv -export-xlsx $output/report.xlsx